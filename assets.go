@@ -0,0 +1,262 @@
+package gotenberg
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlTagRegexp       = regexp.MustCompile(`(?is)<([a-z][a-z0-9:-]*)\b([^>]*)>`)
+	htmlAttributeRegexp = regexp.MustCompile(`(?is)(?:^|\s)(src|href|xlink:href|srcset|poster|data|style)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+	cssURLRegexp        = regexp.MustCompile(`(?is)url\(\s*(?:"([^"]*)"|'([^']*)'|([^)"'\s]*))\s*\)`)
+	cssImportRegexp     = regexp.MustCompile(`(?is)@import\s+(?:"([^"]*)"|'([^']*)')`)
+	schemeRegexp        = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
+	scriptBodyRegexp    = regexp.MustCompile(`(?is)<script\b[^>]*>(.*?)</script\s*>`)
+	styleBodyRegexp     = regexp.MustCompile(`(?is)<style\b[^>]*>(.*?)</style\s*>`)
+)
+
+// referenceFunc is called for every resource reference
+// found in a document, and returns its replacement.
+type referenceFunc func(ref string) (string, error)
+
+// rewriteHTMLReferences calls fn for every resource referenced
+// by an HTML document, either through a tag attribute or the CSS
+// of style blocks and attributes, and replaces the reference with
+// the value returned by fn. Script bodies are left untouched.
+func rewriteHTMLReferences(data string, fn referenceFunc) (string, error) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range scriptBodyRegexp.FindAllStringSubmatchIndex(data, -1) {
+		markup, err := rewriteHTMLMarkup(data[last:loc[2]], fn)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(markup)
+		b.WriteString(data[loc[2]:loc[3]])
+		last = loc[3]
+	}
+	markup, err := rewriteHTMLMarkup(data[last:], fn)
+	if err != nil {
+		return "", err
+	}
+	b.WriteString(markup)
+	return b.String(), nil
+}
+
+// rewriteHTMLMarkup rewrites the references of HTML
+// containing no script body.
+func rewriteHTMLMarkup(data string, fn referenceFunc) (string, error) {
+	data, err := replaceSubmatches(htmlTagRegexp, data, 2, func(attrs string, tag []string) (string, error) {
+		name := strings.ToLower(tag[1])
+		return replaceSubmatches(htmlAttributeRegexp, attrs, -1, func(value string, attr []string) (string, error) {
+			switch strings.ToLower(attr[1]) {
+			case "href", "xlink:href":
+				// Only links to stylesheets, icons, etc. and SVG references
+				// are resources: anchors and base URLs are not.
+				if name != "link" && name != "use" && name != "image" {
+					return value, nil
+				}
+			case "srcset":
+				return rewriteSrcset(value, fn)
+			case "style":
+				return rewriteCSSReferences(value, fn)
+			}
+			return fn(value)
+		})
+	})
+	if err != nil {
+		return "", err
+	}
+	return replaceSubmatches(styleBodyRegexp, data, 1, func(css string, _ []string) (string, error) {
+		return rewriteCSSReferences(css, fn)
+	})
+}
+
+// rewriteCSSReferences calls fn for every resource referenced
+// by a CSS document, either through url() or @import, and replaces
+// the reference with the value returned by fn.
+func rewriteCSSReferences(data string, fn referenceFunc) (string, error) {
+	replace := func(value string, _ []string) (string, error) {
+		return fn(value)
+	}
+	data, err := replaceSubmatches(cssURLRegexp, data, -1, replace)
+	if err != nil {
+		return "", err
+	}
+	return replaceSubmatches(cssImportRegexp, data, -1, replace)
+}
+
+func rewriteSrcset(srcset string, fn referenceFunc) (string, error) {
+	candidates := strings.Split(srcset, ",")
+	for i, candidate := range candidates {
+		fields := strings.Fields(candidate)
+		if len(fields) == 0 {
+			continue
+		}
+		ref, err := fn(fields[0])
+		if err != nil {
+			return "", err
+		}
+		fields[0] = ref
+		candidates[i] = strings.Join(fields, " ")
+	}
+	return strings.Join(candidates, ", "), nil
+}
+
+// replaceSubmatches replaces, for every match of re, either the given
+// submatch or, if group is negative, the last submatch which participated
+// in the match with the value returned by fn.
+func replaceSubmatches(re *regexp.Regexp, data string, group int, fn func(value string, submatches []string) (string, error)) (string, error) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringSubmatchIndex(data, -1) {
+		submatches := make([]string, len(loc)/2)
+		for i := range submatches {
+			if loc[2*i] >= 0 {
+				submatches[i] = data[loc[2*i]:loc[2*i+1]]
+			}
+		}
+		g := group
+		if g < 0 {
+			for i := len(submatches) - 1; i > 0; i-- {
+				if loc[2*i] >= 0 {
+					g = i
+					break
+				}
+			}
+		}
+		if g < 0 || loc[2*g] < 0 {
+			continue
+		}
+		value, err := fn(submatches[g], submatches)
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(data[last:loc[2*g]])
+		b.WriteString(value)
+		last = loc[2*g+1]
+	}
+	b.WriteString(data[last:])
+	return b.String(), nil
+}
+
+// isRemoteReference reports whether a reference points
+// to a resource that has to be fetched over the network.
+func isRemoteReference(ref string) bool {
+	lower := strings.ToLower(strings.TrimSpace(ref))
+	return strings.HasPrefix(lower, "http://") || strings.HasPrefix(lower, "https://") || strings.HasPrefix(lower, "//")
+}
+
+// isLocalReference reports whether a reference points
+// to a file next to the document referencing it.
+func isLocalReference(ref string) bool {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "//") {
+		return false
+	}
+	return !schemeRegexp.MatchString(ref)
+}
+
+// splitReference splits a local reference into its path and
+// its fragment, dropping the query which is meaningless for files.
+func splitReference(ref string) (string, string) {
+	ref = strings.TrimSpace(ref)
+	var fragment string
+	if i := strings.Index(ref, "#"); i >= 0 {
+		ref, fragment = ref[:i], ref[i:]
+	}
+	if i := strings.Index(ref, "?"); i >= 0 {
+		ref = ref[:i]
+	}
+	return ref, fragment
+}
+
+// assetBundler collects the files referenced by HTML documents
+// of a fs.FS as assets, flattening their paths.
+type assetBundler struct {
+	fsys    fs.FS
+	root    string
+	assets  []Document
+	sources map[string]string
+}
+
+func newAssetBundler(fsys fs.FS, root string) *assetBundler {
+	return &assetBundler{fsys: fsys, root: path.Clean(root), sources: make(map[string]string)}
+}
+
+// document reads an HTML document of the fs.FS and returns it
+// with every local reference flattened, collecting the referenced files.
+func (b *assetBundler) document(filename, name string) (Document, error) {
+	data, err := fs.ReadFile(b.fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: reading file: %v", name, err)
+	}
+	rewritten, err := rewriteHTMLReferences(string(data), b.reference(name))
+	if err != nil {
+		return nil, err
+	}
+	return NewDocumentFromString(filename, rewritten)
+}
+
+// reference returns a referenceFunc which resolves the local references
+// of the given file, collects them and flattens them to their base name.
+func (b *assetBundler) reference(name string) referenceFunc {
+	return func(ref string) (string, error) {
+		if !isLocalReference(ref) {
+			return ref, nil
+		}
+		refPath, fragment := splitReference(ref)
+		if refPath == "" {
+			return ref, nil
+		}
+		var resolved string
+		if strings.HasPrefix(refPath, "/") {
+			resolved = path.Join(b.root, refPath)
+		} else {
+			resolved = path.Join(path.Dir(name), refPath)
+		}
+		if !fs.ValidPath(resolved) {
+			return "", fmt.Errorf("%s: reference %s is outside of the directory", name, ref)
+		}
+		filename := path.Base(resolved)
+		if err := b.add(name, filename, resolved); err != nil {
+			return "", err
+		}
+		return filename + fragment, nil
+	}
+}
+
+func (b *assetBundler) add(name, filename, resolved string) error {
+	if source, ok := b.sources[filename]; ok {
+		if source != resolved {
+			return fmt.Errorf("%s: %s and %s would both be flattened to %s", name, source, resolved, filename)
+		}
+		return nil
+	}
+	b.sources[filename] = resolved
+	if !strings.EqualFold(path.Ext(resolved), ".css") {
+		asset, err := NewDocumentFromFS(b.fsys, resolved)
+		if err != nil {
+			return fmt.Errorf("%s: referenced file: %v", name, err)
+		}
+		b.assets = append(b.assets, asset)
+		return nil
+	}
+	data, err := fs.ReadFile(b.fsys, resolved)
+	if err != nil {
+		return fmt.Errorf("%s: referenced file: %v", name, err)
+	}
+	rewritten, err := rewriteCSSReferences(string(data), b.reference(resolved))
+	if err != nil {
+		return err
+	}
+	asset, err := NewDocumentFromString(filename, rewritten)
+	if err != nil {
+		return err
+	}
+	b.assets = append(b.assets, asset)
+	return nil
+}
@@ -2,19 +2,24 @@ package gotenberg
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 )
 
 // Page Properties
 const (
-	paperWidth        string = "paperWidth"        // Paper width, in inches (default 8.5)
-	paperHeight       string = "paperHeight"       // Paper height, in inches (default 11)
-	marginTop         string = "marginTop"         // Top margin, in inches (default 0.39)
-	marginBottom      string = "marginBottom"      // Bottom margin, in inches (default 0.39)
-	marginLeft        string = "marginLeft"        // Left margin, in inches (default 0.39)
-	marginRight       string = "marginRight"       // Right margin, in inches (default 0.39)
+	paperWidth        string = "paperWidth"        // Paper width, in inches unless a unit is given (default 8.5)
+	paperHeight       string = "paperHeight"       // Paper height, in inches unless a unit is given (default 11)
+	marginTop         string = "marginTop"         // Top margin, in inches unless a unit is given (default 0.39)
+	marginBottom      string = "marginBottom"      // Bottom margin, in inches unless a unit is given (default 0.39)
+	marginLeft        string = "marginLeft"        // Left margin, in inches unless a unit is given (default 0.39)
+	marginRight       string = "marginRight"       // Right margin, in inches unless a unit is given (default 0.39)
 	preferCssPageSize string = "preferCssPageSize" // Define whether to prefer page size as defined by CSS (default false)
 	printBackground   string = "printBackground"   // Print the background graphics (default false)
 	omitBackground    string = "omitBackground"    // Hide the default white background and allow generating PDFs with transparency (default false)
@@ -25,8 +30,9 @@ const (
 
 // Wait
 const (
-	waitDelay         string = "waitDelay"         // Duration to wait when loading an HTML document before converting it to PDF
-	waitForExpression string = "waitForExpression" // The JavaScript expression to wait before converting an HTML document to PDF until it returns true
+	waitDelay            string = "waitDelay"            // Duration to wait when loading an HTML document before converting it to PDF
+	waitForExpression    string = "waitForExpression"    // The JavaScript expression to wait before converting an HTML document to PDF until it returns true
+	skipNetworkIdleEvent string = "skipNetworkIdleEvent" // Do not wait for Chromium network to be idle (default false)
 )
 
 // HTTP Headers
@@ -35,27 +41,157 @@ const (
 	extraHttpHeaders string = "extraHttpHeaders" //  HTTP headers to send by Chromium while loading the HTML document (JSON format)
 )
 
-// Javascript
-const failOnConsoleExceptions string = "failOnConsoleExceptions" // Return a 409 Conflict response if there are exceptions in the Chromium console (default false)
+// Cookies
+const cookies string = "cookies" // Cookies to store in the Chromium cookie jar (JSON format)
+
+// Failure Policies
+const (
+	failOnConsoleExceptions       string = "failOnConsoleExceptions"       // Return a 409 Conflict response if there are exceptions in the Chromium console (default false)
+	failOnHttpStatusCodes         string = "failOnHttpStatusCodes"         // Return a 409 Conflict response if the HTTP status code of the main page matches (JSON format, default [499,599])
+	failOnResourceHttpStatusCodes string = "failOnResourceHttpStatusCodes" // Return a 409 Conflict response if the HTTP status code of a resource matches (JSON format)
+	failOnResourceLoadingFailed   string = "failOnResourceLoadingFailed"   // Return a 409 Conflict response if a resource fails to load (default false)
+)
 
 // CSS
-const emulatedMediaType string = "emulatedMediaType" // The media type to emulate, either "screen" or "print" - empty means "print"
+const (
+	emulatedMediaType     string = "emulatedMediaType"     // The media type to emulate, either "screen" or "print" - empty means "print"
+	emulatedMediaFeatures string = "emulatedMediaFeatures" // The media features to emulate (JSON format)
+)
 
 // Custom Tags
-const extraLinkTags string = "extraLinkTags" // Add custom tags
+const (
+	extraLinkTags   string = "extraLinkTags"   // Add custom link tags (JSON format)
+	extraScriptTags string = "extraScriptTags" // Add custom script tags (JSON format)
+)
 
 // PDF
-const pdfFormat string = "pdfFormat" // The PDF format of the resulting PDF
+const (
+	pdfFormat               string = "pdfFormat"               // The PDF format of the resulting PDF
+	pdfUA                   string = "pdfua"                   // Enable PDF for Universal Access for optimal accessibility (default false)
+	generateDocumentOutline string = "generateDocumentOutline" // Embed the document outline into the PDF (default false)
+	generateTaggedPdf       string = "generateTaggedPdf"       // Generate a tagged (accessible) PDF (default false)
+	singlePage              string = "singlePage"              // Print the entire content in one single page (default false)
+)
+
+// CookieSameSite is the SameSite attribute of a Cookie.
+type CookieSameSite string
 
-// Metadata
-const metaData string = "metadata" // Metadata (title, author, ...) of the resulting PDF
+// SameSite attributes.
+const (
+	SameSiteStrict CookieSameSite = "Strict"
+	SameSiteLax    CookieSameSite = "Lax"
+	SameSiteNone   CookieSameSite = "None"
+)
+
+// Cookie is a cookie Chromium stores
+// before loading the document.
+type Cookie struct {
+	Name     string         `json:"name"`
+	Value    string         `json:"value"`
+	Domain   string         `json:"domain"`
+	Path     string         `json:"path,omitempty"`
+	Secure   bool           `json:"secure,omitempty"`
+	HTTPOnly bool           `json:"httpOnly,omitempty"`
+	SameSite CookieSameSite `json:"sameSite,omitempty"`
+}
+
+// CookiesFromJar returns the cookies of an http.CookieJar
+// Chromium should send when loading the given URL.
+// An http.CookieJar does not expose the original path, HttpOnly
+// and SameSite attributes of its cookies: Path is left empty, so
+// that Chromium uses the default path of the URL, and HTTPOnly
+// and SameSite are lost.
+func CookiesFromJar(jar http.CookieJar, u *url.URL) []Cookie {
+	jarCookies := jar.Cookies(u)
+	res := make([]Cookie, 0, len(jarCookies))
+	for _, c := range jarCookies {
+		res = append(res, Cookie{
+			Name:   c.Name,
+			Value:  c.Value,
+			Domain: u.Hostname(),
+			Secure: u.Scheme == "https",
+		})
+	}
+	return res
+}
+
+// MediaType is a CSS media type Chromium can emulate.
+type MediaType string
+
+// Media types.
+const (
+	MediaTypePrint  MediaType = "print"
+	MediaTypeScreen MediaType = "screen"
+)
+
+// MediaFeature is a CSS media feature Chromium can emulate.
+type MediaFeature struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Media features.
+// nolint:gochecknoglobals
+var (
+	PrefersColorSchemeLight          = MediaFeature{"prefers-color-scheme", "light"}
+	PrefersColorSchemeDark           = MediaFeature{"prefers-color-scheme", "dark"}
+	PrefersReducedMotionNoPreference = MediaFeature{"prefers-reduced-motion", "no-preference"}
+	PrefersReducedMotionReduce       = MediaFeature{"prefers-reduced-motion", "reduce"}
+	PrefersContrastMore              = MediaFeature{"prefers-contrast", "more"}
+	PrefersContrastLess              = MediaFeature{"prefers-contrast", "less"}
+	PrefersReducedTransparencyReduce = MediaFeature{"prefers-reduced-transparency", "reduce"}
+	PrefersReducedDataReduce         = MediaFeature{"prefers-reduced-data", "reduce"}
+	ForcedColorsActive               = MediaFeature{"forced-colors", "active"}
+	ForcedColorsNone                 = MediaFeature{"forced-colors", "none"}
+	ColorGamutSRGB                   = MediaFeature{"color-gamut", "srgb"}
+	ColorGamutP3                     = MediaFeature{"color-gamut", "p3"}
+)
+
+// HTTPStatusCode is an HTTP status code used by failure policies.
+// A X99 value matches every status code between X00 and X99.
+type HTTPStatusCode int
+
+// HTTP status code ranges.
+const (
+	HTTPStatusCodes1xx HTTPStatusCode = 199
+	HTTPStatusCodes2xx HTTPStatusCode = 299
+	HTTPStatusCodes3xx HTTPStatusCode = 399
+	HTTPStatusCodes4xx HTTPStatusCode = 499
+	HTTPStatusCodes5xx HTTPStatusCode = 599
+)
+
+func marshalHTTPStatusCodes(codes []HTTPStatusCode) (string, error) {
+	for _, code := range codes {
+		if code < 100 || code > 599 {
+			return "", fmt.Errorf("%d: invalid HTTP status code", code)
+		}
+	}
+	if codes == nil {
+		codes = []HTTPStatusCode{}
+	}
+	b, err := json.Marshal(codes)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// LinkTag is a link tag added to the HTML document,
+// e.g. a stylesheet from a CDN.
+type LinkTag struct {
+	Href        string `json:"href"`
+	Rel         string `json:"rel,omitempty"`
+	Type        string `json:"type,omitempty"`
+	CrossOrigin string `json:"crossorigin,omitempty"`
+	Integrity   string `json:"integrity,omitempty"`
+}
 
-type MetaData struct {
-	Title    string `json:"Title,omitempty"`
-	Author   string `json:"Author,omitempty"`
-	Producer string `json:"Producer,omitempty"`
-	Creator  string `json:"Creator,omitempty"`
-	Subject  string `json:"Subject,omitempty"`
+// ScriptTag is a script tag added to the HTML document.
+type ScriptTag struct {
+	Src         string `json:"src"`
+	Type        string `json:"type,omitempty"`
+	CrossOrigin string `json:"crossorigin,omitempty"`
+	Integrity   string `json:"integrity,omitempty"`
 }
 
 // Paper Sizes
@@ -95,14 +231,17 @@ var (
 )
 
 type chromiumRequest struct {
-	header Document
-	footer Document
+	header       Document
+	footer       Document
+	extraHeaders map[string]string
+	linkTags     []LinkTag
+	scriptTags   []ScriptTag
 
 	*request
 }
 
 func newChromiumRequest() *chromiumRequest {
-	return &chromiumRequest{header: nil, footer: nil, request: newRequest()}
+	return &chromiumRequest{header: nil, footer: nil, extraHeaders: make(map[string]string), request: newRequest()}
 }
 
 // Header sets header form file.
@@ -130,6 +269,22 @@ func (req *chromiumRequest) Margins(margins [4]float64) {
 	req.values[marginRight] = fmt.Sprintf("%f", margins[3])
 }
 
+// PageSize sets paperWidth and paperHeight form fields,
+// preserving their unit.
+func (req *chromiumRequest) PageSize(size PageSize) {
+	req.values[paperWidth] = size.Width.String()
+	req.values[paperHeight] = size.Height.String()
+}
+
+// PageMargins sets marginTop, marginBottom, marginLeft
+// and marginRight form fields, preserving their unit.
+func (req *chromiumRequest) PageMargins(margins PageMargins) {
+	req.values[marginTop] = margins.Top.String()
+	req.values[marginBottom] = margins.Bottom.String()
+	req.values[marginLeft] = margins.Left.String()
+	req.values[marginRight] = margins.Right.String()
+}
+
 // Landscape sets landscape form field.
 func (req *chromiumRequest) Landscape(isLandscape bool) {
 	req.values[landscape] = strconv.FormatBool(isLandscape)
@@ -140,6 +295,16 @@ func (req *chromiumRequest) NativePageRanges(ranges string) {
 	req.values[nativePageRanges] = ranges
 }
 
+// Pages sets pageRanges form field from typed page ranges.
+// Nil means all pages.
+func (req *chromiumRequest) Pages(ranges *PageRanges) {
+	if ranges == nil {
+		delete(req.values, nativePageRanges)
+		return
+	}
+	req.values[nativePageRanges] = ranges.chromiumString()
+}
+
 // Scale sets scale form field
 func (req *chromiumRequest) Scale(scaleFactor float64) {
 	req.values[scale] = fmt.Sprintf("%f", scaleFactor)
@@ -170,6 +335,17 @@ func (req *chromiumRequest) WaitForExpression(expression string) {
 	req.values[waitForExpression] = expression
 }
 
+// WaitFor sets waitForExpression form field
+// from conditions which must all be met.
+func (req *chromiumRequest) WaitFor(conditions ...WaitCondition) {
+	req.values[waitForExpression] = And(conditions...).Expression()
+}
+
+// SkipNetworkIdleEvent sets skipNetworkIdleEvent form field
+func (req *chromiumRequest) SkipNetworkIdleEvent(isSkipNetworkIdleEvent bool) {
+	req.values[skipNetworkIdleEvent] = strconv.FormatBool(isSkipNetworkIdleEvent)
+}
+
 // UserAgent sets userAgent form field
 // e.g.: userAgent="Mozilla/5.0 (iPhone; CPU iPhone OS 11_0 like Mac OS X) AppleWebKit/604.1.38
 //
@@ -184,19 +360,201 @@ func (req *chromiumRequest) ExtraHttpHeaders(headers string) {
 	req.values[extraHttpHeaders] = headers
 }
 
+// AddExtraHTTPHeader adds an HTTP header sent by Chromium
+// while loading the HTML document and its resources.
+// It takes precedence over the ExtraHttpHeaders JSON and
+// the client ExtraHTTPHeaders.
+func (req *chromiumRequest) AddExtraHTTPHeader(name, value string) error {
+	if err := validateHTTPHeader(name, value); err != nil {
+		return err
+	}
+	req.extraHeaders[http.CanonicalHeaderKey(name)] = value
+	return nil
+}
+
+// AddExtraHTTPHeaders adds HTTP headers sent by Chromium
+// while loading the HTML document and its resources.
+// See AddExtraHTTPHeader. Nothing is added if a header is invalid.
+func (req *chromiumRequest) AddExtraHTTPHeaders(headers map[string]string) error {
+	for name, value := range headers {
+		if err := validateHTTPHeader(name, value); err != nil {
+			return err
+		}
+	}
+	for name, value := range headers {
+		req.extraHeaders[http.CanonicalHeaderKey(name)] = value
+	}
+	return nil
+}
+
+// AddScopedExtraHTTPHeader adds an HTTP header sent by Chromium only
+// for the URLs matching the scope regular expression (Gotenberg >= 8).
+// See AddExtraHTTPHeader.
+func (req *chromiumRequest) AddScopedExtraHTTPHeader(name, value, scope string) error {
+	if err := validateHTTPHeader(name, value); err != nil {
+		return err
+	}
+	if _, err := regexp.Compile(scope); err != nil {
+		return fmt.Errorf("%s: invalid scope: %v", name, err)
+	}
+	req.extraHeaders[http.CanonicalHeaderKey(name)] = fmt.Sprintf("%s;scope=%s", value, scope)
+	return nil
+}
+
+// extraHTTPHeadersField returns the extraHttpHeaders form field,
+// merging the client defaults, the ExtraHttpHeaders JSON and the
+// typed headers, or an empty string if there is no header at all.
+// Header names are canonicalized, so that they override each other
+// whatever their case.
+func (req *chromiumRequest) extraHTTPHeadersField(defaults map[string]string) (string, error) {
+	headers := make(map[string]string)
+	for name, value := range defaults {
+		if err := validateHTTPHeader(name, value); err != nil {
+			return "", err
+		}
+		headers[http.CanonicalHeaderKey(name)] = value
+	}
+	if raw, ok := req.values[extraHttpHeaders]; ok && raw != "" {
+		var rawHeaders map[string]string
+		if err := json.Unmarshal([]byte(raw), &rawHeaders); err != nil {
+			return "", fmt.Errorf("%s: invalid JSON object of strings: %v", extraHttpHeaders, err)
+		}
+		for name, value := range rawHeaders {
+			headers[http.CanonicalHeaderKey(name)] = value
+		}
+	}
+	for name, value := range req.extraHeaders {
+		headers[name] = value
+	}
+	if len(headers) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(headers)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// validateHTTPHeader checks that name is a valid HTTP header
+// name, and that value does not contain any line break.
+func validateHTTPHeader(name, value string) error {
+	if name == "" {
+		return errors.New("HTTP header name is empty")
+	}
+	for _, r := range name {
+		if r > 127 || !isHTTPTokenChar(byte(r)) {
+			return fmt.Errorf("%q: invalid HTTP header name", name)
+		}
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return fmt.Errorf("%s: HTTP header value contains a line break", name)
+	}
+	return nil
+}
+
+func isHTTPTokenChar(c byte) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		return true
+	default:
+		return strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+	}
+}
+
+// Cookies sets cookies form field.
+func (req *chromiumRequest) Cookies(c ...Cookie) error {
+	for _, cookie := range c {
+		if cookie.Name == "" || cookie.Domain == "" {
+			return errors.New("cookie name and domain are required")
+		}
+	}
+	b, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	req.values[cookies] = string(b)
+
+	return nil
+}
+
 // FailOnConsoleExceptions sets failOnConsoleExceptions form field
 func (req *chromiumRequest) FailOnConsoleExceptions(isFailOnConsoleExceptions bool) {
 	req.values[failOnConsoleExceptions] = strconv.FormatBool(isFailOnConsoleExceptions)
 }
 
+// FailOnHTTPStatusCodes sets failOnHttpStatusCodes form field.
+// No code means the conversion never fails because of the status code of the main page.
+func (req *chromiumRequest) FailOnHTTPStatusCodes(codes ...HTTPStatusCode) error {
+	value, err := marshalHTTPStatusCodes(codes)
+	if err != nil {
+		return err
+	}
+	req.values[failOnHttpStatusCodes] = value
+	return nil
+}
+
+// FailOnResourceHTTPStatusCodes sets failOnResourceHttpStatusCodes form field.
+func (req *chromiumRequest) FailOnResourceHTTPStatusCodes(codes ...HTTPStatusCode) error {
+	value, err := marshalHTTPStatusCodes(codes)
+	if err != nil {
+		return err
+	}
+	req.values[failOnResourceHttpStatusCodes] = value
+	return nil
+}
+
+// FailOnResourceLoadingFailed sets failOnResourceLoadingFailed form field
+func (req *chromiumRequest) FailOnResourceLoadingFailed(isFailOnResourceLoadingFailed bool) {
+	req.values[failOnResourceLoadingFailed] = strconv.FormatBool(isFailOnResourceLoadingFailed)
+}
+
 // EmulatedMediaType sets emulatedMediaType form field
-func (req *chromiumRequest) EmulatedMediaType(mediaType string) {
-	req.values[emulatedMediaType] = mediaType
+func (req *chromiumRequest) EmulatedMediaType(mediaType MediaType) {
+	req.values[emulatedMediaType] = string(mediaType)
+}
+
+// EmulatedMediaFeatures sets emulatedMediaFeatures form field
+func (req *chromiumRequest) EmulatedMediaFeatures(features ...MediaFeature) error {
+	for _, feature := range features {
+		if feature.Name == "" || feature.Value == "" {
+			return errors.New("media feature name and value are required")
+		}
+	}
+	b, err := json.Marshal(features)
+	if err != nil {
+		return err
+	}
+
+	req.values[emulatedMediaFeatures] = string(b)
+
+	return nil
 }
 
-// PDFFormat sets pdfFormat form field
-func (req *chromiumRequest) PDFFormat(format string) {
-	req.values[pdfFormat] = format
+// PDFFormat sets pdfFormat form field, sent as pdfa to Gotenberg 8
+func (req *chromiumRequest) PDFFormat(format PDFFormat) {
+	req.values[pdfFormat] = string(format)
+}
+
+// PDFUA sets pdfua form field
+func (req *chromiumRequest) PDFUA(isPDFUA bool) {
+	req.values[pdfUA] = strconv.FormatBool(isPDFUA)
+}
+
+// GenerateDocumentOutline sets generateDocumentOutline form field
+func (req *chromiumRequest) GenerateDocumentOutline(isGenerateDocumentOutline bool) {
+	req.values[generateDocumentOutline] = strconv.FormatBool(isGenerateDocumentOutline)
+}
+
+// GenerateTaggedPDF sets generateTaggedPdf form field
+func (req *chromiumRequest) GenerateTaggedPDF(isGenerateTaggedPDF bool) {
+	req.values[generateTaggedPdf] = strconv.FormatBool(isGenerateTaggedPDF)
+}
+
+// SinglePage sets singlePage form field
+func (req *chromiumRequest) SinglePage(isSinglePage bool) {
+	req.values[singlePage] = strconv.FormatBool(isSinglePage)
 }
 
 // ExtraLinkTags set up custom tags!
@@ -205,14 +563,93 @@ func (req *chromiumRequest) ExtraLinkTags(link string) {
 	req.values[extraLinkTags] = link
 }
 
-// MetaData sets metadata form field (title, author, ...)
-func (req *chromiumRequest) MetaData(m MetaData) error {
-	i, err := json.Marshal(m)
+// AddLinkTag adds a tag to extraLinkTags form field.
+// It replaces the value set with ExtraLinkTags.
+func (req *chromiumRequest) AddLinkTag(tag LinkTag) error {
+	if tag.Href == "" {
+		return errors.New("link tag href is required")
+	}
+	tags := append(req.linkTags, tag)
+	b, err := json.Marshal(tags)
+	if err != nil {
+		return err
+	}
+
+	req.linkTags = tags
+	req.values[extraLinkTags] = string(b)
+
+	return nil
+}
+
+// AddScriptTag adds a tag to extraScriptTags form field.
+func (req *chromiumRequest) AddScriptTag(tag ScriptTag) error {
+	if tag.Src == "" {
+		return errors.New("script tag src is required")
+	}
+	tags := append(req.scriptTags, tag)
+	b, err := json.Marshal(tags)
 	if err != nil {
 		return err
 	}
 
-	req.values[metaData] = string(i)
+	req.scriptTags = tags
+	req.values[extraScriptTags] = string(b)
 
 	return nil
 }
+
+// MetaData sets metadata form field (title, author, ...)
+func (req *chromiumRequest) MetaData(m MetaData) error {
+	return req.metaData(m)
+}
+
+// Validate checks the form fields of the request.
+func (req *chromiumRequest) Validate() error {
+	v := &validation{}
+	req.validate(v)
+	return v.err()
+}
+
+func (req *chromiumRequest) validate(v *validation) {
+	req.request.validate(v)
+	for _, field := range []string{paperWidth, paperHeight} {
+		if value, ok := req.values[field]; ok {
+			v.check(field, validateLength(value, false))
+		}
+	}
+	for _, field := range []string{marginTop, marginBottom, marginLeft, marginRight} {
+		if value, ok := req.values[field]; ok {
+			v.check(field, validateLength(value, true))
+		}
+	}
+	if value, ok := req.values[scale]; ok {
+		v.check(scale, validateFloat(value, 0.1, 2))
+	}
+	if value := req.values[nativePageRanges]; value != "" {
+		v.check(nativePageRanges, validatePageRanges(value))
+	}
+	if value, ok := req.values[waitDelay]; ok {
+		v.check(waitDelay, validateDuration(value))
+	}
+	if value := req.values[extraHttpHeaders]; value != "" {
+		v.check(extraHttpHeaders, validateJSONObject(value))
+	}
+	for _, value := range req.extraHeaders {
+		if strings.Contains(value, ";scope=") {
+			req.since(v, extraHttpHeaders, Gotenberg8)
+			break
+		}
+	}
+	if _, ok := req.values[cookies]; ok {
+		req.since(v, cookies, Gotenberg8)
+	}
+	if req.values[pdfUA] == "true" {
+		req.since(v, pdfUA, Gotenberg8)
+	}
+	switch value := MediaType(req.values[emulatedMediaType]); value {
+	case "", MediaTypePrint, MediaTypeScreen:
+	default:
+		v.addf(emulatedMediaType, "%q: must be %q or %q", value, MediaTypePrint, MediaTypeScreen)
+	}
+	v.check(pdfFormat, validatePDFFormat(req.values[pdfFormat], req.targetVersion()))
+}
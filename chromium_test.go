@@ -0,0 +1,128 @@
+package gotenberg
+
+import (
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChromiumCookies(t *testing.T) {
+	jar, err := cookiejar.New(nil)
+	require.Nil(t, err)
+	u, err := url.Parse("https://example.com/dashboard")
+	require.Nil(t, err)
+	jar.SetCookies(u, []*http.Cookie{{Name: "session", Value: "foo"}})
+	req := NewConvertURLRequest(u.String())
+	err = req.Cookies(CookiesFromJar(jar, u)...)
+	require.Nil(t, err)
+	assert.Equal(t, `[{"name":"session","value":"foo","domain":"example.com","secure":true}]`, req.formValues()[cookies])
+	err = req.Cookies(Cookie{Name: "session", Value: "foo"})
+	assert.NotNil(t, err)
+}
+
+func TestChromiumExtraHTTPHeaders(t *testing.T) {
+	c := &Client{ExtraHTTPHeaders: map[string]string{"X-Tenant": "default", "X-Client": "foo"}}
+	req := NewConvertURLRequest("https://example.com")
+	req.ExtraHttpHeaders(`{"X-Raw": "raw", "X-Client": "bar"}`)
+	require.Nil(t, req.AddExtraHTTPHeaders(map[string]string{"X-Tenant": "acme"}))
+	require.Nil(t, req.AddScopedExtraHTTPHeader("Authorization", "Bearer token", `https?://api\.example\.com/.*`))
+	values, err := c.formValues(req)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{
+		"X-Tenant": "acme",
+		"X-Client": "bar",
+		"X-Raw": "raw",
+		"Authorization": "Bearer token;scope=https?://api\\.example\\.com/.*"
+	}`, values[extraHttpHeaders])
+
+	assert.NotNil(t, req.AddExtraHTTPHeader("X Invalid", "foo"))
+	assert.NotNil(t, req.AddExtraHTTPHeader("X-Invalid", "foo\r\nbar"))
+	assert.NotNil(t, req.AddScopedExtraHTTPHeader("X-Invalid", "foo", "("))
+	req.ExtraHttpHeaders(`{"X-Raw": }`)
+	_, err = c.formValues(req)
+	assert.NotNil(t, err)
+}
+
+func TestChromiumExtraHTTPHeadersCase(t *testing.T) {
+	c := &Client{ExtraHTTPHeaders: map[string]string{"X-Tenant": "default", "x-client": "foo"}}
+	req := NewConvertURLRequest("https://example.com")
+	req.ExtraHttpHeaders(`{"X-CLIENT": "bar"}`)
+	require.Nil(t, req.AddExtraHTTPHeader("x-tenant", "acme"))
+	values, err := c.formValues(req)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"X-Tenant": "acme", "X-Client": "bar"}`, values[extraHttpHeaders])
+	assert.NotNil(t, req.AddExtraHTTPHeaders(map[string]string{"X-A": "a", "X-B": "b", "X Invalid": "c"}))
+	assert.Empty(t, req.extraHeaders["X-A"])
+	assert.Empty(t, req.extraHeaders["X-B"])
+}
+
+func TestChromiumTags(t *testing.T) {
+	req := NewConvertURLRequest("https://example.com")
+	require.Nil(t, req.AddLinkTag(LinkTag{Href: "https://cdn.example.com/a.css?x=1&y=2", Rel: "stylesheet"}))
+	require.Nil(t, req.AddLinkTag(LinkTag{Href: "https://cdn.example.com/b.css", Integrity: "sha384-foo", CrossOrigin: "anonymous"}))
+	require.Nil(t, req.AddScriptTag(ScriptTag{Src: "https://cdn.example.com/a.js"}))
+	assert.JSONEq(t, `[
+		{"href": "https://cdn.example.com/a.css?x=1&y=2", "rel": "stylesheet"},
+		{"href": "https://cdn.example.com/b.css", "integrity": "sha384-foo", "crossorigin": "anonymous"}
+	]`, req.formValues()[extraLinkTags])
+	assert.JSONEq(t, `[{"src": "https://cdn.example.com/a.js"}]`, req.formValues()[extraScriptTags])
+	assert.NotNil(t, req.AddLinkTag(LinkTag{Rel: "stylesheet"}))
+}
+
+func TestChromiumFailurePolicies(t *testing.T) {
+	req := NewConvertURLRequest("https://example.com")
+	require.Nil(t, req.FailOnHTTPStatusCodes(HTTPStatusCodes4xx, HTTPStatusCodes5xx))
+	require.Nil(t, req.FailOnResourceHTTPStatusCodes(404))
+	req.FailOnResourceLoadingFailed(true)
+	assert.Equal(t, "[499,599]", req.formValues()[failOnHttpStatusCodes])
+	assert.Equal(t, "[404]", req.formValues()[failOnResourceHttpStatusCodes])
+	assert.Equal(t, "true", req.formValues()[failOnResourceLoadingFailed])
+	require.Nil(t, req.FailOnHTTPStatusCodes())
+	assert.Equal(t, "[]", req.formValues()[failOnHttpStatusCodes])
+	assert.NotNil(t, req.FailOnHTTPStatusCodes(600))
+}
+
+func TestChromiumEmulation(t *testing.T) {
+	req := NewConvertURLRequest("https://example.com")
+	req.EmulatedMediaType(MediaTypeScreen)
+	require.Nil(t, req.EmulatedMediaFeatures(PrefersColorSchemeDark, PrefersReducedMotionReduce))
+	req.SkipNetworkIdleEvent(true)
+	assert.Equal(t, "screen", req.formValues()[emulatedMediaType])
+	assert.Equal(t, `[{"name":"prefers-color-scheme","value":"dark"},{"name":"prefers-reduced-motion","value":"reduce"}]`, req.formValues()[emulatedMediaFeatures])
+	assert.Equal(t, "true", req.formValues()[skipNetworkIdleEvent])
+	assert.NotNil(t, req.EmulatedMediaFeatures(MediaFeature{Name: "prefers-color-scheme"}))
+	assert.Nil(t, req.Validate())
+	req.EmulatedMediaType("tv")
+	assert.NotNil(t, req.Validate())
+}
+
+func TestChromiumValidate(t *testing.T) {
+	req := NewConvertURLRequest("https://example.com")
+	req.GenerateDocumentOutline(true)
+	req.GenerateTaggedPDF(true)
+	req.PDFUA(true)
+	req.SinglePage(true)
+	req.NativePageRanges("1-2")
+	// PDF/UA requires Gotenberg 8.
+	assert.NotNil(t, req.Validate())
+	req.PDFUA(false)
+	assert.Nil(t, req.Validate())
+	// Gotenberg does not reject these combinations.
+	req.GenerateTaggedPDF(false)
+	assert.Nil(t, req.Validate())
+	req.NativePageRanges("banana")
+	assert.NotNil(t, req.Validate())
+	req.NativePageRanges("")
+	req.PDFFormat(PDFA1b)
+	assert.NotNil(t, req.Validate())
+	req.PDFFormat(PDFA1a)
+	assert.Nil(t, req.Validate())
+	req.target(Gotenberg8)
+	assert.NotNil(t, req.Validate())
+	req.PDFFormat(PDFA1b)
+	assert.Nil(t, req.Validate())
+}
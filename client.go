@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"mime/multipart"
 	"net/http"
 	"os"
@@ -25,9 +26,24 @@ const (
 	webhookURLBaseHTTPHeaderKey string = "Gotenberg-Webhookurl-"
 )
 
+// Encryption
+const (
+	userPassword  string = "userPassword"  // Password for opening the resulting PDF
+	ownerPassword string = "ownerPassword" // Password for full access on the resulting PDF
+)
+
 type Client struct {
 	Hostname   string
 	HTTPClient *http.Client
+	// ExtraHTTPHeaders are sent by Chromium while loading the
+	// document of every Chromium request. Headers set on a
+	// request take precedence.
+	ExtraHTTPHeaders map[string]string
+	// Version is the targeted Gotenberg version, which
+	// requests are validated against, rejecting the options
+	// it lacks, and which names some form fields. Zero means
+	// DefaultVersion.
+	Version Version
 }
 
 func NewClient(hostname string, httpClient *http.Client) *Client {
@@ -38,24 +54,56 @@ func NewClient(hostname string, httpClient *http.Client) *Client {
 }
 
 type Request interface {
+	// Validate checks the form fields of the request, and returns
+	// a *ValidationError listing every invalid one. It is called
+	// by Client.Post before sending anything, against the version
+	// of the client, or else against DefaultVersion.
+	Validate() error
 	postURL() string
 	customHTTPHeaders() map[string]string
 	formValues() map[string]string
+	formSecrets() map[string]string
 	formFiles() map[string]Document
+	formFieldFiles() map[string][]Document
+	target(version Version)
 }
 
 type request struct {
 	httpHeaders map[string]string
 	values      map[string]string
+	secrets     *secrets
+	// fieldFiles are the files sent under another
+	// form field than files, e.g. embeds.
+	fieldFiles map[string][]Document
+	// version is the targeted Gotenberg version.
+	version Version
 }
 
 func newRequest() *request {
 	return &request{
 		httpHeaders: make(map[string]string),
 		values:      make(map[string]string),
+		secrets:     &secrets{make(map[string]string)},
+		fieldFiles:  make(map[string][]Document),
 	}
 }
 
+// secrets holds the form fields which must never be
+// printed or logged, e.g. passwords. They are kept
+// apart from the other form values, and behind a
+// pointer so that fmt does not print them.
+type secrets struct {
+	values map[string]string
+}
+
+func (s *secrets) String() string {
+	return "[REDACTED]"
+}
+
+func (s *secrets) GoString() string {
+	return s.String()
+}
+
 // ResultFilename sets resultFilename form field.
 func (req *request) ResultFilename(filename string) {
 	req.httpHeaders[resultFilename] = filename
@@ -97,6 +145,63 @@ func (req *request) AddWebhookURLHTTPHeader(key, value string) {
 	req.httpHeaders[key] = value
 }
 
+// Validate checks the form fields common to every request.
+func (req *request) Validate() error {
+	v := &validation{}
+	req.validate(v)
+	return v.err()
+}
+
+func (req *request) validate(v *validation) {
+	if value, ok := req.httpHeaders[waitTimeout]; ok {
+		v.check(waitTimeout, validateFloat(value, 0, math.MaxFloat64))
+	}
+	for _, field := range []string{webhookURL, webhookErrorURL} {
+		if value, ok := req.httpHeaders[field]; ok {
+			v.check(field, validateHTTPURL(value))
+		}
+	}
+	for _, field := range []string{webhookMethod, webhookErrorMethod} {
+		if value, ok := req.httpHeaders[field]; ok {
+			v.check(field, validateWebhookMethod(value))
+		}
+	}
+	if value, ok := req.httpHeaders[webhookExtraHeaders]; ok {
+		v.check(webhookExtraHeaders, validateJSONObject(value))
+	}
+	if value, ok := req.secrets.values[userPassword]; ok {
+		req.since(v, userPassword, Gotenberg8)
+		if value == "" {
+			v.addf(userPassword, "must not be empty")
+		}
+	}
+	if req.hasDownloads() {
+		req.since(v, downloadFrom, Gotenberg8)
+	}
+	if len(req.fieldFiles[embeds]) > 0 {
+		req.since(v, embeds, Gotenberg8)
+	}
+	for _, field := range []string{watermark, stamp} {
+		if req.values[field+sourceSuffix] != "" {
+			req.since(v, field, Gotenberg8)
+		}
+	}
+	for field, docs := range req.fieldFiles {
+		v.checkDocuments(field, docs)
+	}
+}
+
+// Encrypt sets userPassword and ownerPassword form fields, which
+// protect the resulting PDF. ownerPassword may be empty.
+func (req *request) Encrypt(userPwd, ownerPwd string) {
+	req.secrets.values[userPassword] = userPwd
+	if ownerPwd == "" {
+		delete(req.secrets.values, ownerPassword)
+		return
+	}
+	req.secrets.values[ownerPassword] = ownerPwd
+}
+
 func (req *request) customHTTPHeaders() map[string]string {
 	return req.httpHeaders
 }
@@ -105,8 +210,30 @@ func (req *request) formValues() map[string]string {
 	return req.values
 }
 
+func (req *request) formSecrets() map[string]string {
+	return req.secrets.values
+}
+
+func (req *request) formFieldFiles() map[string][]Document {
+	return req.fieldFiles
+}
+
+// extraHTTPHeadersRequest is implemented by the requests
+// accepting the extraHttpHeaders form field.
+type extraHTTPHeadersRequest interface {
+	extraHTTPHeadersField(defaults map[string]string) (string, error)
+}
+
 func (c *Client) Post(ctx context.Context, req Request) (*http.Response, error) {
-	body, contentType, err := multipartForm(req)
+	req.target(c.version())
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	values, err := c.formValues(req)
+	if err != nil {
+		return nil, err
+	}
+	body, contentType, err := multipartForm(req.formFiles(), req.formFieldFiles(), values)
 	if err != nil {
 		return nil, err
 	}
@@ -140,13 +267,13 @@ func (c *Client) Store(ctx context.Context, req Request, dest string) error {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return errors.New("failed to generate the result PDF")
+		return newError(resp)
 	}
 	return writeNewFile(dest, resp.Body)
 }
 
 func hasWebhook(req Request) bool {
-	wURL, ok := req.formValues()[webhookURL]
+	wURL, ok := req.customHTTPHeaders()[webhookURL]
 	if !ok {
 		return false
 	}
@@ -173,28 +300,68 @@ func writeNewFile(fpath string, in io.Reader) error {
 	return nil
 }
 
-func multipartForm(req Request) (*bytes.Buffer, string, error) {
+// formValues returns the form values of the request,
+// including its secrets, completed with the client defaults
+// and named as expected by the client version.
+func (c *Client) formValues(req Request) (map[string]string, error) {
+	values := make(map[string]string)
+	for name, value := range req.formValues() {
+		values[name] = value
+	}
+	for name, value := range req.formSecrets() {
+		values[name] = value
+	}
+	if hreq, ok := req.(extraHTTPHeadersRequest); ok {
+		headers, err := hreq.extraHTTPHeadersField(c.ExtraHTTPHeaders)
+		if err != nil {
+			return nil, err
+		}
+		delete(values, extraHttpHeaders)
+		if headers != "" {
+			values[extraHttpHeaders] = headers
+		}
+	}
+	versionFormValues(values, c.version())
+	return values, nil
+}
+
+func multipartForm(files map[string]Document, fieldFiles map[string][]Document, values map[string]string) (*bytes.Buffer, string, error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 	defer writer.Close() // nolint: errcheck
-	for filename, doc := range req.formFiles() {
-		in, err := doc.Reader()
-		if err != nil {
-			return nil, "", fmt.Errorf("%s: creating reader: %v", filename, err)
-		}
-		part, err := writer.CreateFormFile("files", filename)
-		if err != nil {
-			return nil, "", fmt.Errorf("%s: creating form file: %v", filename, err)
+	for filename, doc := range files {
+		if err := writeFormFile(writer, "files", filename, doc); err != nil {
+			return nil, "", err
 		}
-		_, err = io.Copy(part, in)
-		if err != nil {
-			return nil, "", fmt.Errorf("%s: copying data: %v", filename, err)
+	}
+	for field, docs := range fieldFiles {
+		for _, doc := range docs {
+			if err := writeFormFile(writer, field, doc.Filename(), doc); err != nil {
+				return nil, "", err
+			}
 		}
 	}
-	for name, value := range req.formValues() {
+	for name, value := range values {
 		if err := writer.WriteField(name, value); err != nil {
 			return nil, "", fmt.Errorf("%s: writing form field: %v", name, err)
 		}
 	}
 	return body, writer.FormDataContentType(), nil
 }
+
+func writeFormFile(writer *multipart.Writer, field, filename string, doc Document) error {
+	in, err := doc.Reader()
+	if err != nil {
+		return fmt.Errorf("%s: creating reader: %v", filename, err)
+	}
+	defer in.Close() // nolint: errcheck
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		return fmt.Errorf("%s: creating form file: %v", filename, err)
+	}
+	_, err = io.Copy(part, in)
+	if err != nil {
+		return fmt.Errorf("%s: copying data: %v", filename, err)
+	}
+	return nil
+}
@@ -2,9 +2,14 @@ package gotenberg
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"html/template"
 	"io"
+	"io/fs"
+	"mime/multipart"
 	"os"
+	"path"
 	"strings"
 )
 
@@ -91,6 +96,179 @@ func (doc *documentFromBytes) Reader() (io.ReadCloser, error) {
 	return io.NopCloser(bytes.NewReader(doc.data)), nil
 }
 
+type documentFromReader struct {
+	r        io.Reader
+	consumed bool
+
+	*document
+}
+
+// NewDocumentFromReader creates a Document from
+// an io.Reader. The resulting Document is single-use:
+// its Reader method fails if called more than once.
+func NewDocumentFromReader(filename string, r io.Reader) (Document, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%s: reader is nil", filename)
+	}
+	return &documentFromReader{
+		r,
+		false,
+		&document{filename},
+	}, nil
+}
+
+func (doc *documentFromReader) Reader() (io.ReadCloser, error) {
+	if doc.consumed {
+		return nil, fmt.Errorf("%s: %w", doc.Filename(), ErrDocumentConsumed)
+	}
+	doc.consumed = true
+	if rc, ok := doc.r.(io.ReadCloser); ok {
+		return rc, nil
+	}
+	return io.NopCloser(doc.r), nil
+}
+
+// ErrDocumentConsumed is returned when reading a single-use
+// Document, e.g. one created from an io.Reader, a second time.
+var ErrDocumentConsumed = errors.New("document has already been read and cannot be read again")
+
+type documentFromFS struct {
+	fsys fs.FS
+	name string
+
+	*document
+}
+
+// NewDocumentFromFS creates a Document from a file
+// of a fs.FS, e.g. an embed.FS. The Document filename
+// is the base name of the file.
+func NewDocumentFromFS(fsys fs.FS, name string) (Document, error) {
+	info, err := fs.Stat(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if info.IsDir() {
+		return nil, fmt.Errorf("%s: is a directory", name)
+	}
+	return &documentFromFS{
+		fsys,
+		name,
+		&document{path.Base(name)},
+	}, nil
+}
+
+func (doc *documentFromFS) Reader() (io.ReadCloser, error) {
+	in, err := doc.fsys.Open(doc.name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: opening file: %v", doc.Filename(), err)
+	}
+	return in, nil
+}
+
+// NewAssetsFromFS creates a Document for every file of
+// the root subtree of a fs.FS, e.g. an embed.FS, to be used
+// with ConvertHTMLRequest.Assets. Gotenberg expects assets
+// in a flat directory, so two files with the same base name
+// result in an error.
+func NewAssetsFromFS(fsys fs.FS, root string) ([]Document, error) {
+	var assets []Document
+	seen := make(map[string]string)
+	err := fs.WalkDir(fsys, root, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		asset, err := NewDocumentFromFS(fsys, name)
+		if err != nil {
+			return err
+		}
+		if other, ok := seen[asset.Filename()]; ok {
+			return fmt.Errorf("%s: same filename as %s", name, other)
+		}
+		seen[asset.Filename()] = name
+		assets = append(assets, asset)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: walking directory: %v", root, err)
+	}
+	return assets, nil
+}
+
+type documentFromFileHeader struct {
+	fh *multipart.FileHeader
+
+	*document
+}
+
+// NewDocumentFromFileHeader creates a Document from
+// a file of a parsed multipart form.
+func NewDocumentFromFileHeader(fh *multipart.FileHeader) (Document, error) {
+	if fh == nil {
+		return nil, errors.New("file header is nil")
+	}
+	if fh.Size == 0 {
+		return nil, fmt.Errorf("%s: file is empty", fh.Filename)
+	}
+	return &documentFromFileHeader{
+		fh,
+		&document{path.Base(fh.Filename)},
+	}, nil
+}
+
+func (doc *documentFromFileHeader) Reader() (io.ReadCloser, error) {
+	in, err := doc.fh.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%s: opening file: %v", doc.Filename(), err)
+	}
+	return in, nil
+}
+
+// NewDocumentFromPart creates a Document from a file part
+// of a multipart stream. As the part can only be read once,
+// the resulting Document is single-use, and it must be sent
+// before moving to the next part.
+func NewDocumentFromPart(part *multipart.Part) (Document, error) {
+	if part == nil {
+		return nil, errors.New("part is nil")
+	}
+	if part.FileName() == "" {
+		return nil, fmt.Errorf("%s: part is not a file", part.FormName())
+	}
+	return NewDocumentFromReader(path.Base(part.FileName()), part)
+}
+
+type documentFromTemplate struct {
+	tmpl *template.Template
+	data interface{}
+
+	*document
+}
+
+// NewDocumentFromTemplate creates a Document from
+// an html/template and its data. The template is
+// rendered each time the Document is read.
+func NewDocumentFromTemplate(filename string, tmpl *template.Template, data interface{}) (Document, error) {
+	if tmpl == nil {
+		return nil, fmt.Errorf("%s: template is nil", filename)
+	}
+	return &documentFromTemplate{
+		tmpl,
+		data,
+		&document{filename},
+	}, nil
+}
+
+func (doc *documentFromTemplate) Reader() (io.ReadCloser, error) {
+	buf := &bytes.Buffer{}
+	if err := doc.tmpl.Execute(buf, doc.data); err != nil {
+		return nil, fmt.Errorf("%s: executing template: %v", doc.Filename(), err)
+	}
+	return io.NopCloser(buf), nil
+}
+
 func fileExists(name string) bool {
 	_, err := os.Stat(name)
 	return !os.IsNotExist(err)
@@ -101,4 +279,8 @@ var (
 	_ Document = new(documentFromPath)
 	_ Document = new(documentFromString)
 	_ Document = new(documentFromBytes)
+	_ Document = new(documentFromReader)
+	_ Document = new(documentFromFS)
+	_ Document = new(documentFromFileHeader)
+	_ Document = new(documentFromTemplate)
 )
@@ -0,0 +1,67 @@
+package gotenberg
+
+import (
+	"errors"
+	"html/template"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocumentFromReader(t *testing.T) {
+	doc, err := NewDocumentFromReader("index.html", strings.NewReader("<html>Foo</html>"))
+	require.Nil(t, err)
+	in, err := doc.Reader()
+	require.Nil(t, err)
+	data, err := io.ReadAll(in)
+	require.Nil(t, err)
+	assert.Equal(t, "<html>Foo</html>", string(data))
+	_, err = doc.Reader()
+	assert.True(t, errors.Is(err, ErrDocumentConsumed))
+}
+
+func TestDocumentFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"templates/index.html":     {Data: []byte("<html>Foo</html>")},
+		"templates/css/style.css":  {Data: []byte("body {}")},
+		"templates/img/img.gif":    {Data: []byte("GIF89a")},
+		"templates/other/img.gif":  {Data: []byte("GIF89a")},
+		"templates/fonts/font.ttf": {Data: []byte("font")},
+	}
+	doc, err := NewDocumentFromFS(fsys, "templates/index.html")
+	require.Nil(t, err)
+	assert.Equal(t, "index.html", doc.Filename())
+	_, err = NewDocumentFromFS(fsys, "templates/missing.html")
+	assert.NotNil(t, err)
+	_, err = NewDocumentFromFS(fsys, "templates")
+	assert.NotNil(t, err)
+
+	assets, err := NewAssetsFromFS(fsys, "templates/css")
+	require.Nil(t, err)
+	require.Len(t, assets, 1)
+	assert.Equal(t, "style.css", assets[0].Filename())
+	_, err = NewAssetsFromFS(fsys, "templates")
+	assert.NotNil(t, err)
+}
+
+func TestDocumentFromTemplate(t *testing.T) {
+	tmpl := template.Must(template.New("header").Parse("<html>{{ .Name }}</html>"))
+	doc, err := NewDocumentFromTemplate("header.html", tmpl, map[string]string{"Name": "<Foo>"})
+	require.Nil(t, err)
+	in, err := doc.Reader()
+	require.Nil(t, err)
+	data, err := io.ReadAll(in)
+	require.Nil(t, err)
+	assert.Equal(t, "<html>&lt;Foo&gt;</html>", string(data))
+
+	tmpl = template.Must(template.New("footer").Parse("<html>{{ .Name.Missing }}</html>"))
+	doc, err = NewDocumentFromTemplate("footer.html", tmpl, map[string]string{"Name": "Foo"})
+	require.Nil(t, err)
+	_, err = doc.Reader()
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "footer.html: executing template")
+}
@@ -0,0 +1,56 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Download from
+const (
+	downloadFrom string = "downloadFrom" // Remote files Gotenberg downloads instead of receiving them (JSON format)
+)
+
+// Download is a remote file Gotenberg downloads itself,
+// e.g. a large file reachable from its container.
+type Download struct {
+	URL string `json:"url"`
+	// ExtraHTTPHeaders are sent with the download
+	// request only, e.g. an authorization header.
+	ExtraHTTPHeaders map[string]string `json:"extraHttpHeaders,omitempty"`
+}
+
+// DownloadFrom sets downloadFrom form field. The downloaded
+// files are added to the uploaded documents, if any.
+// Calling it without downloads removes the form field.
+// As its headers often hold credentials, the form field
+// is kept with the secrets of the request.
+func (req *request) DownloadFrom(downloads ...Download) error {
+	if len(downloads) == 0 {
+		delete(req.secrets.values, downloadFrom)
+		return nil
+	}
+	for _, d := range downloads {
+		if err := validateHTTPURL(d.URL); err != nil {
+			return fmt.Errorf("%s: %v", downloadFrom, err)
+		}
+		for name, value := range d.ExtraHTTPHeaders {
+			if err := validateHTTPHeader(name, value); err != nil {
+				return fmt.Errorf("%s: %s: %v", downloadFrom, d.URL, err)
+			}
+		}
+	}
+	b, err := json.Marshal(downloads)
+	if err != nil {
+		return err
+	}
+
+	req.secrets.values[downloadFrom] = string(b)
+
+	return nil
+}
+
+// hasDownloads reports whether Gotenberg downloads remote
+// files, which may stand in for the uploaded documents.
+func (req *request) hasDownloads() bool {
+	return req.secrets.values[downloadFrom] != ""
+}
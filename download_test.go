@@ -0,0 +1,55 @@
+package gotenberg
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDownloadFrom(t *testing.T) {
+	req := NewOfficeRequest()
+	req.target(Gotenberg8)
+	assert.NotNil(t, req.Validate())
+	err := req.DownloadFrom(
+		Download{URL: "https://files.example.com/report.docx", ExtraHTTPHeaders: map[string]string{"Authorization": "Bearer token"}},
+		Download{URL: "http://storage:9000/slides.pptx"},
+	)
+	require.Nil(t, err)
+	assert.NotContains(t, req.formValues(), "downloadFrom")
+	assert.NotContains(t, fmt.Sprintf("%+v %#v", req, req.request), "Bearer")
+	values, err := (&Client{}).formValues(req)
+	require.Nil(t, err)
+	assert.JSONEq(t, `[
+		{"url":"https://files.example.com/report.docx","extraHttpHeaders":{"Authorization":"Bearer token"}},
+		{"url":"http://storage:9000/slides.pptx"}
+	]`, values["downloadFrom"])
+	assert.Nil(t, req.Validate())
+	req.target(Gotenberg7)
+	assert.NotNil(t, req.Validate())
+	req.target(Gotenberg8)
+	err = req.DownloadFrom(Download{URL: "/report.docx"})
+	require.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "webhook")
+	assert.NotNil(t, req.DownloadFrom(Download{URL: "https://example.com/a.docx", ExtraHTTPHeaders: map[string]string{"Bad Name": "x"}}))
+	require.Nil(t, req.DownloadFrom())
+	assert.NotContains(t, req.formSecrets(), "downloadFrom")
+	assert.NotNil(t, req.Validate())
+}
+
+func TestDownloadFromRoutes(t *testing.T) {
+	download := Download{URL: "https://files.example.com/file"}
+	html := NewConvertHTMLRequest(nil)
+	html.target(Gotenberg8)
+	require.Nil(t, html.DownloadFrom(download))
+	assert.Nil(t, html.Validate())
+	assert.NotContains(t, html.formFiles(), "index.html")
+	merge := NewMergeRequest()
+	merge.target(Gotenberg8)
+	require.Nil(t, merge.DownloadFrom(download))
+	assert.Nil(t, merge.Validate())
+	page := NewConvertURLRequest("https://example.com")
+	page.target(Gotenberg8)
+	require.Nil(t, page.DownloadFrom(download))
+	assert.NotNil(t, page.Validate())
+}
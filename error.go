@@ -0,0 +1,143 @@
+package gotenberg
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxErrorBodySize limits how much of an error
+// response body is read into an Error.
+const maxErrorBodySize int64 = 64 << 10
+
+// Error is returned when the Gotenberg API
+// answers with a non-successful HTTP status code.
+type Error struct {
+	// StatusCode is the HTTP status code returned by Gotenberg.
+	StatusCode int
+	// Message is the body of the Gotenberg response, if any.
+	Message string
+	// Failure is decoded from 409 Conflict responses, which Gotenberg
+	// returns when a failure policy is triggered. It is nil otherwise.
+	Failure *Failure
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("gotenberg: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("gotenberg: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// newError creates an Error from a Gotenberg response.
+// It does not close the response body.
+func newError(resp *http.Response) *Error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize)) // nolint: errcheck
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(body)),
+	}
+	if e.StatusCode == http.StatusConflict {
+		e.Failure = newFailure(e.Message)
+	}
+	return e
+}
+
+// FailureReason is the failure policy
+// which triggered a 409 Conflict response.
+type FailureReason string
+
+const (
+	// FailureHTTPStatusCode means the main page answered with a status
+	// code matching FailOnHTTPStatusCodes.
+	FailureHTTPStatusCode FailureReason = "http-status-code"
+	// FailureResourceHTTPStatusCode means at least one resource answered
+	// with a status code matching FailOnResourceHTTPStatusCodes.
+	FailureResourceHTTPStatusCode FailureReason = "resource-http-status-code"
+	// FailureResourceLoadingFailed means at least one resource could not
+	// be loaded, see FailOnResourceLoadingFailed.
+	FailureResourceLoadingFailed FailureReason = "resource-loading-failed"
+	// FailureConsoleExceptions means there were exceptions in the Chromium
+	// console, see FailOnConsoleExceptions.
+	FailureConsoleExceptions FailureReason = "console-exceptions"
+	// FailureUnknown means the response could not be decoded.
+	FailureUnknown FailureReason = "unknown"
+)
+
+// Failure details a 409 Conflict response.
+type Failure struct {
+	Reason FailureReason
+	// StatusCode is the status code of the main page, if relevant.
+	StatusCode int
+	// Details lists the offending resources or console exceptions,
+	// one per line of the response.
+	Details []string
+}
+
+var failurePrefixes = []struct {
+	prefix string
+	reason FailureReason
+}{
+	{"invalid http status code from the main page", FailureHTTPStatusCode},
+	{"invalid http status code from resources", FailureResourceHTTPStatusCode},
+	{"chromium failed to load resources", FailureResourceLoadingFailed},
+	{"chromium console exceptions", FailureConsoleExceptions},
+}
+
+var statusCodeRegexp = regexp.MustCompile(`\b[1-5][0-9]{2}\b`)
+
+// newFailure decodes the body of a 409 Conflict response.
+func newFailure(message string) *Failure {
+	failure := &Failure{Reason: FailureUnknown}
+	lower := strings.ToLower(message)
+	for _, p := range failurePrefixes {
+		if strings.HasPrefix(lower, p.prefix) {
+			failure.Reason = p.reason
+			message = strings.TrimPrefix(strings.TrimSpace(message[len(p.prefix):]), ":")
+			break
+		}
+	}
+	for _, line := range strings.Split(message, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			failure.Details = append(failure.Details, line)
+		}
+	}
+	if failure.Reason == FailureHTTPStatusCode {
+		if code, err := strconv.Atoi(statusCodeRegexp.FindString(message)); err == nil {
+			failure.StatusCode = code
+		}
+	}
+	return failure
+}
+
+// FieldError is an invalid form field, or
+// option, of a request.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError is returned by Request.Validate,
+// and lists every invalid form field of the request.
+type ValidationError struct {
+	Errors []*FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("invalid request: %s", strings.Join(msgs, "; "))
+}
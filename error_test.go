@@ -0,0 +1,17 @@
+package gotenberg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorFailure(t *testing.T) {
+	failure := newFailure("Invalid HTTP status code from the main page: 404: Not Found")
+	assert.Equal(t, &Failure{Reason: FailureHTTPStatusCode, StatusCode: 404, Details: []string{"404: Not Found"}}, failure)
+	failure = newFailure("Invalid HTTP status code from resources:\nhttps://example.com/a.css - 404: Not Found\nhttps://example.com/b.js - 500: Internal Server Error")
+	assert.Equal(t, FailureResourceHTTPStatusCode, failure.Reason)
+	assert.Equal(t, []string{"https://example.com/a.css - 404: Not Found", "https://example.com/b.js - 500: Internal Server Error"}, failure.Details)
+	failure = newFailure("Something else")
+	assert.Equal(t, FailureUnknown, failure.Reason)
+}
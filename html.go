@@ -1,5 +1,13 @@
 package gotenberg
 
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+)
+
 type ConvertHTMLRequest struct {
 	index  Document
 	assets []Document
@@ -11,6 +19,61 @@ func NewConvertHTMLRequest(index Document) *ConvertHTMLRequest {
 	return &ConvertHTMLRequest{index: index, assets: []Document{}, chromiumRequest: newChromiumRequest()}
 }
 
+// NewConvertHTMLRequestFromFS creates a ConvertHTMLRequest from
+// the index.html, header.html and footer.html files of a directory
+// of a fs.FS; only index.html is required. The stylesheets, fonts,
+// images, etc. they reference with a relative path, directly or
+// through CSS, are attached as assets. As Gotenberg expects assets
+// in a flat directory, the references are rewritten accordingly.
+func NewConvertHTMLRequestFromFS(fsys fs.FS, dir string) (*ConvertHTMLRequest, error) {
+	bundler := newAssetBundler(fsys, dir)
+	index, err := bundler.document("index.html", path.Join(dir, "index.html"))
+	if err != nil {
+		return nil, err
+	}
+	req := NewConvertHTMLRequest(index)
+	header, err := optionalHTMLDocument(bundler, "header.html", dir)
+	if err != nil {
+		return nil, err
+	}
+	req.header = header
+	footer, err := optionalHTMLDocument(bundler, "footer.html", dir)
+	if err != nil {
+		return nil, err
+	}
+	req.footer = footer
+	req.Assets(bundler.assets...)
+	return req, nil
+}
+
+// NewConvertHTMLRequestFromDir creates a ConvertHTMLRequest from
+// a directory of the host filesystem.
+// See NewConvertHTMLRequestFromFS for more details.
+func NewConvertHTMLRequestFromDir(dir string) (*ConvertHTMLRequest, error) {
+	return NewConvertHTMLRequestFromFS(os.DirFS(dir), ".")
+}
+
+func optionalHTMLDocument(bundler *assetBundler, filename, dir string) (Document, error) {
+	name := path.Join(dir, filename)
+	if _, err := fs.Stat(bundler.fsys, name); errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return bundler.document(filename, name)
+}
+
+// Validate checks the form fields and the documents of the request.
+func (req *ConvertHTMLRequest) Validate() error {
+	v := &validation{}
+	req.chromiumRequest.validate(v)
+	if req.index == nil && !req.hasDownloads() {
+		v.addf("index.html", "document is nil")
+	}
+	v.checkDocuments("assets", req.assets)
+	return v.err()
+}
+
 func (req *ConvertHTMLRequest) postURL() string {
 	return "/forms/chromium/convert/html"
 }
@@ -22,7 +85,9 @@ func (req *ConvertHTMLRequest) Assets(assets ...Document) {
 
 func (req *ConvertHTMLRequest) formFiles() map[string]Document {
 	files := make(map[string]Document)
-	files["index.html"] = req.index
+	if req.index != nil {
+		files["index.html"] = req.index
+	}
 	if req.header != nil {
 		files["header.html"] = req.header
 	}
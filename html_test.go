@@ -3,8 +3,11 @@ package gotenberg
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/commitsmart/gotenberg-go-client/test"
 
@@ -74,7 +77,7 @@ func TestHTMLComplete(t *testing.T) {
 	req.UserAgent("Mozilla")
 	req.FailOnConsoleExceptions(true)
 	req.EmulatedMediaType("print")
-	req.PDFFormat("PDF/A-1a")
+	req.PDFFormat(PDFA1a)
 	dirPath, err := test.Rand()
 	require.Nil(t, err)
 	dest := fmt.Sprintf("%s/foo.pdf", dirPath)
@@ -95,3 +98,90 @@ func TestHTMLPageRanges(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+func TestHTMLFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"site/index.html":      {Data: []byte(`<html><head><link rel="stylesheet" href="css/style.css?v=2"></head><body><img src="img/logo.png"><img srcset="img/logo.png 1x, img/logo@2x.png 2x"><a href="other.html">Other</a><img src="https://example.com/remote.png"></body></html>`)},
+		"site/footer.html":     {Data: []byte(`<html><body><img src="/img/logo.png"></body></html>`)},
+		"site/css/style.css":   {Data: []byte(`@font-face { src: url("../fonts/font.woff#iefix"); }`)},
+		"site/img/logo.png":    {Data: []byte("png")},
+		"site/img/logo@2x.png": {Data: []byte("png")},
+		"site/fonts/font.woff": {Data: []byte("woff")},
+	}
+	req, err := NewConvertHTMLRequestFromFS(fsys, "site")
+	require.Nil(t, err)
+	files := req.formFiles()
+	assert.Len(t, files, 6)
+	assert.Nil(t, req.header)
+	index, err := files["index.html"].Reader()
+	require.Nil(t, err)
+	data, err := io.ReadAll(index)
+	require.Nil(t, err)
+	assert.Equal(t, `<html><head><link rel="stylesheet" href="style.css"></head><body><img src="logo.png"><img srcset="logo.png 1x, logo@2x.png 2x"><a href="other.html">Other</a><img src="https://example.com/remote.png"></body></html>`, string(data))
+	style, err := files["style.css"].Reader()
+	require.Nil(t, err)
+	data, err = io.ReadAll(style)
+	require.Nil(t, err)
+	assert.Equal(t, `@font-face { src: url("font.woff#iefix"); }`, string(data))
+
+	fsys["site/other/logo.png"] = &fstest.MapFile{Data: []byte("png")}
+	fsys["site/header.html"] = &fstest.MapFile{Data: []byte(`<html><body><img src="other/logo.png"></body></html>`)}
+	_, err = NewConvertHTMLRequestFromFS(fsys, "site")
+	assert.NotNil(t, err)
+}
+
+func TestHTMLFromFSScript(t *testing.T) {
+	fsys := fstest.MapFS{
+		"site/index.html": {Data: []byte(`<style>body { background: url(img/bg.png); }</style><div style="background: url('img/bg.png')">url(not/a/file)</div><script src="js/app.js">const s = "url(api/data)";</script>`)},
+		"site/img/bg.png": {Data: []byte("png")},
+		"site/js/app.js":  {Data: []byte("")},
+	}
+	req, err := NewConvertHTMLRequestFromFS(fsys, "site")
+	require.Nil(t, err)
+	index, err := req.formFiles()["index.html"].Reader()
+	require.Nil(t, err)
+	data, err := io.ReadAll(index)
+	require.Nil(t, err)
+	assert.Equal(t, `<style>body { background: url(bg.png); }</style><div style="background: url('bg.png')">url(not/a/file)</div><script src="app.js">const s = "url(api/data)";</script>`, string(data))
+}
+
+func TestHTMLLint(t *testing.T) {
+	index, err := NewDocumentFromPath("index.html", test.HTMLTestFilePath(t, "index.html"))
+	require.Nil(t, err)
+	req := NewConvertHTMLRequest(index)
+	header, err := NewDocumentFromPath("header.html", test.HTMLTestFilePath(t, "header.html"))
+	require.Nil(t, err)
+	req.Header(header)
+	footer, err := NewDocumentFromString("footer.html", `<p><span class="pageNumber"></span></p>`)
+	require.Nil(t, err)
+	req.Footer(footer)
+	style, err := NewDocumentFromPath("style.css", test.HTMLTestFilePath(t, "style.css"))
+	require.Nil(t, err)
+	req.Assets(style)
+	issues, err := req.Lint()
+	require.Nil(t, err)
+	assert.Equal(t, []LintIssue{
+		{Kind: LintRemoteResource, Filename: "index.html", Reference: "https://fonts.googleapis.com/css?family=Montserrat"},
+		{Kind: LintMissingAsset, Filename: "index.html", Reference: "img.gif"},
+		{Kind: LintRemoteResource, Filename: "index.html", Reference: "https://gutendev.com/wp-content/uploads/2018/10/01_03-1.jpg"},
+		{Kind: LintIncompleteDocument, Filename: "footer.html"},
+		{Kind: LintNestedReference, Filename: "style.css", Reference: "html/font.woff"},
+	}, issues)
+}
+
+func TestHTMLLintSingleUse(t *testing.T) {
+	index, err := NewDocumentFromReader("index.html", strings.NewReader(`<img src="img.gif">`))
+	require.Nil(t, err)
+	req := NewConvertHTMLRequest(index)
+	issues, err := req.Lint()
+	require.Nil(t, err)
+	assert.Equal(t, []LintIssue{{Kind: LintNotInspected, Filename: "index.html"}}, issues)
+	req.Assets(nil)
+	_, err = req.Lint()
+	assert.NotNil(t, err)
+	in, err := index.Reader()
+	require.Nil(t, err)
+	data, err := io.ReadAll(in)
+	require.Nil(t, err)
+	assert.Equal(t, `<img src="img.gif">`, string(data))
+}
@@ -0,0 +1,173 @@
+package gotenberg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// LintIssueKind is the kind of a LintIssue.
+type LintIssueKind string
+
+const (
+	// LintMissingAsset means a relative reference
+	// does not match any attached asset.
+	LintMissingAsset LintIssueKind = "missing-asset"
+	// LintNestedReference means a relative reference points
+	// to a subdirectory, which Gotenberg will not resolve as
+	// all files end up in the same directory.
+	LintNestedReference LintIssueKind = "nested-reference"
+	// LintRemoteResource means an absolute http(s) reference
+	// requires network access from the Gotenberg container.
+	LintRemoteResource LintIssueKind = "remote-resource"
+	// LintIncompleteDocument means a header or footer is not
+	// a full HTML document, which Chromium requires.
+	LintIncompleteDocument LintIssueKind = "incomplete-document"
+	// LintNotInspected means a document is single-use, e.g.
+	// created from an io.Reader, and was left unread so
+	// that the request can still be sent.
+	LintNotInspected LintIssueKind = "not-inspected"
+)
+
+// LintIssue is a problem found by ConvertHTMLRequest.Lint.
+type LintIssue struct {
+	Kind LintIssueKind
+	// Filename is the document in which the issue was found.
+	Filename string
+	// Reference is the offending reference, if any.
+	Reference string
+}
+
+func (issue LintIssue) String() string {
+	switch issue.Kind {
+	case LintMissingAsset:
+		return fmt.Sprintf("%s: %s is not attached as an asset", issue.Filename, issue.Reference)
+	case LintNestedReference:
+		return fmt.Sprintf("%s: %s is in a subdirectory, reference it as %s", issue.Filename, issue.Reference, path.Base(issue.Reference))
+	case LintRemoteResource:
+		return fmt.Sprintf("%s: %s requires network access from Gotenberg", issue.Filename, issue.Reference)
+	case LintIncompleteDocument:
+		return fmt.Sprintf("%s: not a full HTML document with html, head and body tags", issue.Filename)
+	case LintNotInspected:
+		return fmt.Sprintf("%s: single-use document, not inspected", issue.Filename)
+	default:
+		return fmt.Sprintf("%s: %s %s", issue.Filename, issue.Kind, issue.Reference)
+	}
+}
+
+var (
+	htmlOpeningTagRegexp = regexp.MustCompile(`(?i)<html[\s>]`)
+	headOpeningTagRegexp = regexp.MustCompile(`(?i)<head[\s>]`)
+	bodyOpeningTagRegexp = regexp.MustCompile(`(?i)<body[\s>]`)
+)
+
+// Lint inspects the index, header, footer and CSS assets of the
+// request for resources Gotenberg would quietly fail to load, and
+// for header and footer which are not full HTML documents.
+// Single-use documents, e.g. created from an io.Reader, are
+// not read and are reported as LintNotInspected.
+func (req *ConvertHTMLRequest) Lint() ([]LintIssue, error) {
+	if req.index == nil {
+		return nil, errors.New("index.html: document is nil")
+	}
+	for i, asset := range req.assets {
+		if asset == nil {
+			return nil, fmt.Errorf("assets: document %d is nil", i)
+		}
+	}
+	files := req.formFiles()
+	var issues []LintIssue
+	for _, filename := range []string{"index.html", "header.html", "footer.html"} {
+		doc, ok := files[filename]
+		if !ok {
+			continue
+		}
+		if isSingleUse(doc) {
+			issues = append(issues, LintIssue{Kind: LintNotInspected, Filename: filename})
+			continue
+		}
+		data, err := readDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		if filename != "index.html" && !isFullHTMLDocument(data) {
+			issues = append(issues, LintIssue{Kind: LintIncompleteDocument, Filename: filename})
+		}
+		_, _ = rewriteHTMLReferences(data, lintReference(filename, files, &issues)) // nolint: errcheck
+	}
+	filenames := make([]string, 0, len(req.assets))
+	for _, asset := range req.assets {
+		if strings.EqualFold(path.Ext(asset.Filename()), ".css") {
+			filenames = append(filenames, asset.Filename())
+		}
+	}
+	sort.Strings(filenames)
+	for _, filename := range filenames {
+		if isSingleUse(files[filename]) {
+			issues = append(issues, LintIssue{Kind: LintNotInspected, Filename: filename})
+			continue
+		}
+		data, err := readDocument(files[filename])
+		if err != nil {
+			return nil, err
+		}
+		_, _ = rewriteCSSReferences(data, lintReference(filename, files, &issues)) // nolint: errcheck
+	}
+	return issues, nil
+}
+
+// lintReference returns a referenceFunc which records
+// an issue for every problematic reference.
+func lintReference(filename string, files map[string]Document, issues *[]LintIssue) referenceFunc {
+	return func(ref string) (string, error) {
+		if isRemoteReference(ref) {
+			*issues = append(*issues, LintIssue{Kind: LintRemoteResource, Filename: filename, Reference: ref})
+			return ref, nil
+		}
+		if !isLocalReference(ref) {
+			return ref, nil
+		}
+		refPath, _ := splitReference(ref)
+		refPath = strings.TrimPrefix(refPath, "./")
+		switch {
+		case refPath == "":
+		case strings.Contains(refPath, "/"):
+			*issues = append(*issues, LintIssue{Kind: LintNestedReference, Filename: filename, Reference: ref})
+		default:
+			if _, ok := files[refPath]; !ok {
+				*issues = append(*issues, LintIssue{Kind: LintMissingAsset, Filename: filename, Reference: ref})
+			}
+		}
+		return ref, nil
+	}
+}
+
+func isFullHTMLDocument(data string) bool {
+	return htmlOpeningTagRegexp.MatchString(data) &&
+		headOpeningTagRegexp.MatchString(data) &&
+		bodyOpeningTagRegexp.MatchString(data)
+}
+
+// isSingleUse reports whether a Document can be read only once.
+func isSingleUse(doc Document) bool {
+	_, ok := doc.(*documentFromReader)
+	return ok
+}
+
+// readDocument reads the whole content of a Document.
+func readDocument(doc Document) (string, error) {
+	in, err := doc.Reader()
+	if err != nil {
+		return "", err
+	}
+	defer in.Close() // nolint: errcheck
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return "", fmt.Errorf("%s: reading document: %v", doc.Filename(), err)
+	}
+	return string(data), nil
+}
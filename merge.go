@@ -1,5 +1,18 @@
 package gotenberg
 
+import "strconv"
+
+// PDF Format
+const (
+	pdfFormatMerge string = "pdfFormat" // The PDF format of the resulting PDF
+	pdfUAMerge     string = "pdfua"     // Enable PDF for Universal Access for optimal accessibility (default false)
+)
+
+// Flatten
+const (
+	flattenMerge string = "flatten" // Flatten the form fields and annotations of the resulting PDF (default false)
+)
+
 // MergeRequest facilitates merging PDF
 // with the Gotenberg API.
 type MergeRequest struct {
@@ -13,16 +26,46 @@ func NewMergeRequest(pdfs ...Document) *MergeRequest {
 	return &MergeRequest{pdfs, newRequest()}
 }
 
+// Validate checks the form fields and the documents of the request.
+func (req *MergeRequest) Validate() error {
+	v := &validation{}
+	req.request.validate(v)
+	v.checkPDFs("files", req.pdfs, req.hasDownloads())
+	v.check(pdfFormatMerge, validatePDFFormat(req.values[pdfFormatMerge], req.targetVersion()))
+	for _, field := range []string{pdfUAMerge, flattenMerge} {
+		if req.values[field] == "true" {
+			req.since(v, field, Gotenberg8)
+		}
+	}
+	return v.err()
+}
+
+// PDFFormat sets pdfFormat form field, sent as pdfa to Gotenberg 8.
+func (req *MergeRequest) PDFFormat(format PDFFormat) {
+	req.values[pdfFormatMerge] = string(format)
+}
+
+// PDFUA sets pdfua form field.
+func (req *MergeRequest) PDFUA(isPDFUA bool) {
+	req.values[pdfUAMerge] = strconv.FormatBool(isPDFUA)
+}
+
+// Flatten sets flatten form field.
+func (req *MergeRequest) Flatten(flatten bool) {
+	req.values[flattenMerge] = strconv.FormatBool(flatten)
+}
+
+// MetaData sets metadata form field (title, author, ...)
+func (req *MergeRequest) MetaData(m MetaData) error {
+	return req.metaData(m)
+}
+
 func (req *MergeRequest) postURL() string {
 	return "/forms/pdfengines/merge"
 }
 
 func (req *MergeRequest) formFiles() map[string]Document {
-	files := make(map[string]Document)
-	for _, pdf := range req.pdfs {
-		files[pdf.Filename()] = pdf
-	}
-	return files
+	return pdfFiles(req.pdfs)
 }
 
 // Compile-time checks to ensure type implements desired interfaces.
@@ -42,3 +42,23 @@ func TestMergeWebhook(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 200, resp.StatusCode)
 }
+
+func TestMergeOptions(t *testing.T) {
+	pdf, err := NewDocumentFromString("archive.pdf", "%PDF-1.7")
+	require.Nil(t, err)
+	req := NewMergeRequest(pdf)
+	req.PDFFormat(PDFA2b)
+	req.PDFUA(true)
+	req.Flatten(true)
+	require.Nil(t, req.MetaData(MetaData{Title: "Archive"}))
+	assert.Nil(t, req.Validate())
+	values, err := (&Client{Version: Gotenberg8}).formValues(req)
+	require.Nil(t, err)
+	assert.Equal(t, "PDF/A-2b", values["pdfa"])
+	assert.NotContains(t, values, "pdfFormat")
+	assert.Equal(t, "true", values["pdfua"])
+	assert.Equal(t, "true", values["flatten"])
+	assert.Contains(t, values["metadata"], `"Title":"Archive"`)
+	req.PDFFormat(PDFA1b)
+	assert.NotNil(t, req.Validate())
+}
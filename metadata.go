@@ -0,0 +1,88 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Metadata
+const metaData string = "metadata" // Metadata (title, author, ...) of the resulting PDF
+
+// Trapped is the Trapped entry of the metadata.
+type Trapped string
+
+// Trapped values.
+const (
+	TrappedTrue    Trapped = "True"
+	TrappedFalse   Trapped = "False"
+	TrappedUnknown Trapped = "Unknown"
+)
+
+// MetaData is the metadata of the resulting PDF.
+// Zero values are not sent.
+type MetaData struct {
+	Title        string
+	Author       string
+	Producer     string
+	Creator      string
+	Subject      string
+	Keywords     []string
+	Copyright    string
+	CreationDate time.Time
+	ModDate      time.Time
+	Trapped      Trapped
+	// Custom holds any other metadata key. The
+	// fields above take precedence over it.
+	Custom map[string]interface{}
+}
+
+// MarshalJSON serializes the metadata as expected
+// by Gotenberg, with dates in RFC 3339 format.
+func (m MetaData) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]interface{}, len(m.Custom)+10)
+	for key, value := range m.Custom {
+		fields[key] = value
+	}
+	for key, value := range map[string]string{
+		"Title":     m.Title,
+		"Author":    m.Author,
+		"Producer":  m.Producer,
+		"Creator":   m.Creator,
+		"Subject":   m.Subject,
+		"Copyright": m.Copyright,
+		"Trapped":   string(m.Trapped),
+	} {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	if len(m.Keywords) > 0 {
+		fields["Keywords"] = m.Keywords
+	}
+	if !m.CreationDate.IsZero() {
+		// ExifTool, which writes the metadata, names it CreateDate.
+		fields["CreateDate"] = m.CreationDate.Format(time.RFC3339)
+	}
+	if !m.ModDate.IsZero() {
+		fields["ModDate"] = m.ModDate.Format(time.RFC3339)
+	}
+	return json.Marshal(fields)
+}
+
+// metaData sets metadata form field.
+func (req *request) metaData(m MetaData) error {
+	switch m.Trapped {
+	case "", TrappedTrue, TrappedFalse, TrappedUnknown:
+	default:
+		return fmt.Errorf("%q: invalid Trapped value", m.Trapped)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	req.values[metaData] = string(b)
+
+	return nil
+}
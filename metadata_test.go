@@ -0,0 +1,35 @@
+package gotenberg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetaData(t *testing.T) {
+	m := MetaData{
+		Title:        "Report",
+		Keywords:     []string{"first", "second"},
+		Copyright:    "ACME",
+		CreationDate: time.Date(2006, 9, 18, 16, 27, 50, 0, time.FixedZone("", -4*60*60)),
+		Trapped:      TrappedUnknown,
+		Custom:       map[string]interface{}{"Title": "Overridden", "Department": "Finance"},
+	}
+	req := NewMergeRequest()
+	require.Nil(t, req.MetaData(m))
+	assert.JSONEq(t, `{
+		"Title": "Report",
+		"Keywords": ["first", "second"],
+		"Copyright": "ACME",
+		"CreateDate": "2006-09-18T16:27:50-04:00",
+		"Trapped": "Unknown",
+		"Department": "Finance"
+	}`, req.formValues()[metaData])
+
+	office := NewOfficeRequest()
+	require.Nil(t, office.MetaData(MetaData{Author: "Foo"}))
+	assert.Equal(t, `{"Author":"Foo"}`, office.formValues()[metaData])
+	assert.NotNil(t, office.MetaData(MetaData{Trapped: "Maybe"}))
+}
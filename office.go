@@ -1,6 +1,10 @@
 package gotenberg
 
-import "strconv"
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+)
 
 // Orientation
 const (
@@ -16,6 +20,7 @@ const (
 const (
 	nativePdfFormatOffice string = "nativePdfFormat" // Use unoconv to convert the resulting PDF to the given PDF format
 	pdfFormatOffice       string = "pdfFormat"       // The PDF format of the resulting PDF
+	pdfUAOffice           string = "pdfua"           // Enable PDF for Universal Access for optimal accessibility (default false)
 )
 
 // Merge
@@ -23,6 +28,51 @@ const (
 	mergeOffice string = "merge" // Merge all PDF files into an individual PDF file
 )
 
+// Password
+const (
+	passwordOffice string = "password" // Password for opening the source documents
+)
+
+// Images
+const (
+	losslessImageCompressionOffice string = "losslessImageCompression" // Use lossless compression on images (default false)
+	qualityOffice                  string = "quality"                  // The JPEG compression quality, from 1 to 100 (default 90)
+	reduceImageResolutionOffice    string = "reduceImageResolution"    // Reduce the resolution of images to maxImageResolution (default false)
+	maxImageResolutionOffice       string = "maxImageResolution"       // The maximum resolution of images, in DPI (default 300)
+)
+
+// Export
+const (
+	exportFormFieldsOffice                string = "exportFormFields"                // Export form fields as widgets, or only their fixed print representation (default true)
+	allowDuplicateFieldNamesOffice        string = "allowDuplicateFieldNames"        // Allow multiple form fields exported with the same name (default false)
+	exportBookmarksOffice                 string = "exportBookmarks"                 // Export bookmarks (default true)
+	exportBookmarksToPdfDestinationOffice string = "exportBookmarksToPdfDestination" // Export bookmarks as named destinations (default false)
+	exportPlaceholdersOffice              string = "exportPlaceholders"              // Export the placeholders fields visual markings only (default false)
+	exportNotesOffice                     string = "exportNotes"                     // Export notes (default false)
+	exportNotesPagesOffice                string = "exportNotesPages"                // Export notes pages, Impress documents only (default false)
+	exportOnlyNotesPagesOffice            string = "exportOnlyNotesPages"            // Export only notes pages, if exportNotesPages is true (default false)
+	exportNotesInMarginOffice             string = "exportNotesInMargin"             // Export notes in margin (default false)
+	convertOooTargetToPdfTargetOffice     string = "convertOooTargetToPdfTarget"     // Convert links to other documents to links to the matching PDF files (default false)
+	exportLinksRelativeFsysOffice         string = "exportLinksRelativeFsys"         // Export file system links as relative links (default false)
+	exportHiddenSlidesOffice              string = "exportHiddenSlides"              // Export hidden slides, Impress documents only (default false)
+	skipEmptyPagesOffice                  string = "skipEmptyPages"                  // Suppress automatically inserted empty pages, Writer documents only (default false)
+	addOriginalDocumentAsStreamOffice     string = "addOriginalDocumentAsStream"     // Embed the original document as a stream in the PDF (default false)
+	singlePageSheetsOffice                string = "singlePageSheets"                // Put every sheet on exactly one page, Calc documents only (default false)
+	updateIndexesOffice                   string = "updateIndexes"                   // Update the indexes before the conversion, Writer documents only (default true)
+)
+
+// ImageResolution is a maximum image resolution, in DPI.
+type ImageResolution int
+
+// Image resolutions accepted by LibreOffice.
+const (
+	ImageResolution75   ImageResolution = 75
+	ImageResolution150  ImageResolution = 150
+	ImageResolution300  ImageResolution = 300
+	ImageResolution600  ImageResolution = 600
+	ImageResolution1200 ImageResolution = 1200
+)
+
 type OfficeRequest struct {
 	docs []Document
 
@@ -43,14 +93,29 @@ func (req *OfficeRequest) PageRanges(ranges string) {
 	req.values[nativePageRangesOffice] = ranges
 }
 
-// NativePDFFormat sets nativePdfFormat form field.
-func (req *OfficeRequest) NativePDFFormat(pdfFormat string) {
-	req.values[nativePdfFormatOffice] = pdfFormat
+// Pages sets pageRanges form field from typed page ranges.
+// Nil means all pages.
+func (req *OfficeRequest) Pages(ranges *PageRanges) {
+	if ranges == nil {
+		delete(req.values, nativePageRangesOffice)
+		return
+	}
+	req.values[nativePageRangesOffice] = ranges.libreOfficeString()
+}
+
+// NativePDFFormat sets nativePdfFormat form field (Gotenberg 7 only).
+func (req *OfficeRequest) NativePDFFormat(format PDFFormat) {
+	req.values[nativePdfFormatOffice] = string(format)
+}
+
+// PDFFormat sets pdfFormat form field, sent as pdfa to Gotenberg 8.
+func (req *OfficeRequest) PDFFormat(format PDFFormat) {
+	req.values[pdfFormatOffice] = string(format)
 }
 
-// PDFFormat sets pdfFormat form field.
-func (req *OfficeRequest) PDFFormat(pdfFormat string) {
-	req.values[pdfFormatOffice] = pdfFormat
+// PDFUA sets pdfua form field.
+func (req *OfficeRequest) PDFUA(isPDFUA bool) {
+	req.values[pdfUAOffice] = strconv.FormatBool(isPDFUA)
 }
 
 // Merge sets merge form field.
@@ -58,6 +123,187 @@ func (req *OfficeRequest) Merge(merge bool) {
 	req.values[mergeOffice] = strconv.FormatBool(merge)
 }
 
+// LosslessImageCompression sets losslessImageCompression form field.
+func (req *OfficeRequest) LosslessImageCompression(isLosslessImageCompression bool) {
+	req.values[losslessImageCompressionOffice] = strconv.FormatBool(isLosslessImageCompression)
+}
+
+// Quality sets quality form field, from 1 to 100.
+// It has no effect with lossless image compression.
+func (req *OfficeRequest) Quality(quality int) {
+	req.values[qualityOffice] = strconv.Itoa(quality)
+}
+
+// ReduceImageResolution sets reduceImageResolution form field.
+func (req *OfficeRequest) ReduceImageResolution(isReduceImageResolution bool) {
+	req.values[reduceImageResolutionOffice] = strconv.FormatBool(isReduceImageResolution)
+}
+
+// MaxImageResolution sets maxImageResolution form field.
+// It has no effect unless image resolution is reduced.
+func (req *OfficeRequest) MaxImageResolution(dpi ImageResolution) {
+	req.values[maxImageResolutionOffice] = strconv.Itoa(int(dpi))
+}
+
+// ExportFormFields sets exportFormFields form field.
+func (req *OfficeRequest) ExportFormFields(isExportFormFields bool) {
+	req.values[exportFormFieldsOffice] = strconv.FormatBool(isExportFormFields)
+}
+
+// AllowDuplicateFieldNames sets allowDuplicateFieldNames form field.
+func (req *OfficeRequest) AllowDuplicateFieldNames(isAllowDuplicateFieldNames bool) {
+	req.values[allowDuplicateFieldNamesOffice] = strconv.FormatBool(isAllowDuplicateFieldNames)
+}
+
+// ExportBookmarks sets exportBookmarks form field.
+func (req *OfficeRequest) ExportBookmarks(isExportBookmarks bool) {
+	req.values[exportBookmarksOffice] = strconv.FormatBool(isExportBookmarks)
+}
+
+// ExportBookmarksToPDFDestination sets exportBookmarksToPdfDestination form field.
+func (req *OfficeRequest) ExportBookmarksToPDFDestination(isExportBookmarksToPDFDestination bool) {
+	req.values[exportBookmarksToPdfDestinationOffice] = strconv.FormatBool(isExportBookmarksToPDFDestination)
+}
+
+// ExportPlaceholders sets exportPlaceholders form field.
+func (req *OfficeRequest) ExportPlaceholders(isExportPlaceholders bool) {
+	req.values[exportPlaceholdersOffice] = strconv.FormatBool(isExportPlaceholders)
+}
+
+// ExportNotes sets exportNotes form field.
+func (req *OfficeRequest) ExportNotes(isExportNotes bool) {
+	req.values[exportNotesOffice] = strconv.FormatBool(isExportNotes)
+}
+
+// ExportNotesPages sets exportNotesPages form field.
+func (req *OfficeRequest) ExportNotesPages(isExportNotesPages bool) {
+	req.values[exportNotesPagesOffice] = strconv.FormatBool(isExportNotesPages)
+}
+
+// ExportOnlyNotesPages sets exportOnlyNotesPages form field.
+func (req *OfficeRequest) ExportOnlyNotesPages(isExportOnlyNotesPages bool) {
+	req.values[exportOnlyNotesPagesOffice] = strconv.FormatBool(isExportOnlyNotesPages)
+}
+
+// ExportNotesInMargin sets exportNotesInMargin form field.
+func (req *OfficeRequest) ExportNotesInMargin(isExportNotesInMargin bool) {
+	req.values[exportNotesInMarginOffice] = strconv.FormatBool(isExportNotesInMargin)
+}
+
+// ConvertOooTargetToPDFTarget sets convertOooTargetToPdfTarget form field.
+func (req *OfficeRequest) ConvertOooTargetToPDFTarget(isConvertOooTargetToPDFTarget bool) {
+	req.values[convertOooTargetToPdfTargetOffice] = strconv.FormatBool(isConvertOooTargetToPDFTarget)
+}
+
+// ExportLinksRelativeFsys sets exportLinksRelativeFsys form field.
+func (req *OfficeRequest) ExportLinksRelativeFsys(isExportLinksRelativeFsys bool) {
+	req.values[exportLinksRelativeFsysOffice] = strconv.FormatBool(isExportLinksRelativeFsys)
+}
+
+// ExportHiddenSlides sets exportHiddenSlides form field.
+func (req *OfficeRequest) ExportHiddenSlides(isExportHiddenSlides bool) {
+	req.values[exportHiddenSlidesOffice] = strconv.FormatBool(isExportHiddenSlides)
+}
+
+// SkipEmptyPages sets skipEmptyPages form field.
+func (req *OfficeRequest) SkipEmptyPages(isSkipEmptyPages bool) {
+	req.values[skipEmptyPagesOffice] = strconv.FormatBool(isSkipEmptyPages)
+}
+
+// AddOriginalDocumentAsStream sets addOriginalDocumentAsStream form field.
+func (req *OfficeRequest) AddOriginalDocumentAsStream(isAddOriginalDocumentAsStream bool) {
+	req.values[addOriginalDocumentAsStreamOffice] = strconv.FormatBool(isAddOriginalDocumentAsStream)
+}
+
+// SinglePageSheets sets singlePageSheets form field.
+func (req *OfficeRequest) SinglePageSheets(isSinglePageSheets bool) {
+	req.values[singlePageSheetsOffice] = strconv.FormatBool(isSinglePageSheets)
+}
+
+// UpdateIndexes sets updateIndexes form field.
+func (req *OfficeRequest) UpdateIndexes(isUpdateIndexes bool) {
+	req.values[updateIndexesOffice] = strconv.FormatBool(isUpdateIndexes)
+}
+
+// Password sets password form field, used to open
+// password-protected source documents.
+func (req *OfficeRequest) Password(password string) {
+	if password == "" {
+		delete(req.secrets.values, passwordOffice)
+		return
+	}
+	req.secrets.values[passwordOffice] = password
+}
+
+// SniffDocuments detects the format of the documents from their
+// content and fixes the filename extensions inconsistent with it,
+// e.g. a Word document named "report.pdf" becomes "report.doc".
+func (req *OfficeRequest) SniffDocuments() error {
+	for i, doc := range req.docs {
+		if doc == nil {
+			continue
+		}
+		sniffed, err := SniffOfficeDocument(doc)
+		if err != nil {
+			return err
+		}
+		req.docs[i] = sniffed
+	}
+	return nil
+}
+
+// MetaData sets metadata form field (title, author, ...)
+func (req *OfficeRequest) MetaData(m MetaData) error {
+	return req.metaData(m)
+}
+
+// Validate checks the form fields and the documents of the request.
+func (req *OfficeRequest) Validate() error {
+	v := &validation{}
+	req.request.validate(v)
+	if len(req.docs) == 0 && !req.hasDownloads() {
+		v.addf("files", "at least one document is required")
+	}
+	v.checkDocuments("files", req.docs)
+	for _, doc := range req.docs {
+		if doc != nil && !IsOfficeExtension(filepath.Ext(doc.Filename())) {
+			v.addf("files", "%s: unsupported by LibreOffice", doc.Filename())
+		}
+	}
+	if value := req.values[nativePageRangesOffice]; value != "" {
+		v.check(nativePageRangesOffice, validatePageRanges(value))
+	}
+	if req.targetVersion() >= Gotenberg8 && req.values[nativePdfFormatOffice] != "" {
+		v.addf(nativePdfFormatOffice, "not supported by Gotenberg %d, use PDFFormat instead", req.targetVersion())
+	} else {
+		v.check(nativePdfFormatOffice, validatePDFFormat(req.values[nativePdfFormatOffice], req.targetVersion()))
+	}
+	v.check(pdfFormatOffice, validatePDFFormat(req.values[pdfFormatOffice], req.targetVersion()))
+	if req.values[pdfUAOffice] == "true" {
+		req.since(v, pdfUAOffice, Gotenberg8)
+	}
+	if value, ok := req.values[qualityOffice]; ok {
+		v.check(qualityOffice, validateFloat(value, 1, 100))
+	}
+	if value, ok := req.values[maxImageResolutionOffice]; ok {
+		v.check(maxImageResolutionOffice, validateImageResolution(value))
+	}
+	return v.err()
+}
+
+func validateImageResolution(value string) error {
+	dpi, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%q: invalid resolution", value)
+	}
+	switch ImageResolution(dpi) {
+	case ImageResolution75, ImageResolution150, ImageResolution300, ImageResolution600, ImageResolution1200:
+		return nil
+	default:
+		return fmt.Errorf("%d: must be 75, 150, 300, 600 or 1200 DPI", dpi)
+	}
+}
+
 func (req *OfficeRequest) postURL() string {
 	return "/forms/libreoffice/convert"
 }
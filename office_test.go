@@ -1,12 +1,16 @@
 package gotenberg
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"github.com/commitsmart/gotenberg-go-client/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"io"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -53,3 +57,119 @@ func TestOfficeWebhook(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, 204, resp.StatusCode)
 }
+
+func TestOfficeValidate(t *testing.T) {
+	doc, err := NewDocumentFromPath("document.docx", test.OfficeTestFilePath(t, "document.docx"))
+	require.Nil(t, err)
+	req := NewOfficeRequest(doc)
+	req.PDFFormat(PDFA3b)
+	req.PDFUA(true)
+	assert.Nil(t, req.Validate())
+	req.NativePDFFormat(PDFA1b)
+	assert.NotNil(t, req.Validate())
+	req.NativePDFFormat(PDFA1a)
+	assert.Nil(t, req.Validate())
+	req.target(Gotenberg8)
+	assert.NotNil(t, req.Validate())
+}
+
+func TestOfficeImages(t *testing.T) {
+	doc, err := NewDocumentFromPath("document.docx", test.OfficeTestFilePath(t, "document.docx"))
+	require.Nil(t, err)
+	req := NewOfficeRequest(doc)
+	req.LosslessImageCompression(false)
+	req.Quality(75)
+	req.ReduceImageResolution(true)
+	req.MaxImageResolution(ImageResolution150)
+	assert.Nil(t, req.Validate())
+	assert.Equal(t, "75", req.formValues()[qualityOffice])
+	assert.Equal(t, "150", req.formValues()[maxImageResolutionOffice])
+	req.Quality(0)
+	req.MaxImageResolution(200)
+	err = req.Validate()
+	require.NotNil(t, err)
+	assert.Len(t, err.(*ValidationError).Errors, 2)
+}
+
+func TestOfficeExport(t *testing.T) {
+	req := NewOfficeRequest()
+	req.ExportFormFields(false)
+	req.ExportBookmarksToPDFDestination(true)
+	req.SinglePageSheets(true)
+	req.UpdateIndexes(false)
+	assert.Equal(t, map[string]string{
+		"exportFormFields":                "false",
+		"exportBookmarksToPdfDestination": "true",
+		"singlePageSheets":                "true",
+		"updateIndexes":                   "false",
+	}, req.formValues())
+}
+
+func TestOfficeEncrypt(t *testing.T) {
+	req := NewOfficeRequest()
+	req.Password("s3cr3t-in")
+	req.Encrypt("s3cr3t-user", "s3cr3t-owner")
+	assert.NotContains(t, req.formValues(), "password")
+	assert.NotContains(t, fmt.Sprintf("%+v %#v", req, req.request), "s3cr3t")
+	values, err := (&Client{}).formValues(req)
+	require.Nil(t, err)
+	assert.Equal(t, "s3cr3t-in", values["password"])
+	assert.Equal(t, "s3cr3t-user", values["userPassword"])
+	assert.Equal(t, "s3cr3t-owner", values["ownerPassword"])
+	req.Encrypt("", "s3cr3t-owner")
+	err = req.Validate()
+	require.NotNil(t, err)
+	assert.NotContains(t, err.Error(), "s3cr3t")
+}
+
+func TestOfficeSniff(t *testing.T) {
+	data, err := os.ReadFile(test.OfficeTestFilePath(t, "document.docx"))
+	require.Nil(t, err)
+	docx, err := NewDocumentFromBytes("report.pdf", data)
+	require.Nil(t, err)
+	rtf, err := NewDocumentFromReader("notes.txt", strings.NewReader(`{\rtf1 hello}`))
+	require.Nil(t, err)
+	unknown, err := NewDocumentFromString("data.bin", "hello")
+	require.Nil(t, err)
+	req := NewOfficeRequest(docx, rtf, unknown)
+	require.Nil(t, req.SniffDocuments())
+	assert.Equal(t, "report.docx", req.docs[0].Filename())
+	assert.Equal(t, "notes.rtf", req.docs[1].Filename())
+	r, err := req.docs[1].Reader()
+	require.Nil(t, err)
+	content, err := io.ReadAll(r)
+	require.Nil(t, err)
+	assert.Equal(t, `{\rtf1 hello}`, string(content))
+	err = req.Validate()
+	require.NotNil(t, err)
+	assert.Contains(t, err.Error(), "data.bin")
+	assert.NotContains(t, err.Error(), "report.docx")
+}
+
+func TestOfficeSniffOLE2(t *testing.T) {
+	// The directory sector of this OLE2 file is stored past the
+	// bytes read at first, as in most real documents.
+	data := make([]byte, 201*512+512)
+	copy(data, ole2Signature)
+	binary.LittleEndian.PutUint16(data[30:], 9)
+	binary.LittleEndian.PutUint32(data[48:], 200)
+	copy(data[201*512:], utf16le("Workbook"))
+	xls, err := NewDocumentFromBytes("sheet.doc", data)
+	require.Nil(t, err)
+	sniffed, err := SniffOfficeDocument(xls)
+	require.Nil(t, err)
+	assert.Equal(t, "sheet.xls", sniffed.Filename())
+
+	// Without a readable directory, the filename is kept.
+	unknown, err := NewDocumentFromReader("sheet.xls", bytes.NewReader(data))
+	require.Nil(t, err)
+	sniffed, err = SniffOfficeDocument(unknown)
+	require.Nil(t, err)
+	assert.Equal(t, "sheet.xls", sniffed.Filename())
+	copy(data[201*512:], make([]byte, 16))
+	ppt, err := NewDocumentFromBytes("slides.ppt", data)
+	require.Nil(t, err)
+	sniffed, err = SniffOfficeDocument(ppt)
+	require.Nil(t, err)
+	assert.Equal(t, "slides.ppt", sniffed.Filename())
+}
@@ -0,0 +1,279 @@
+package gotenberg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// officeExtensions lists the extensions of
+// the documents LibreOffice can convert.
+// nolint:gochecknoglobals
+var officeExtensions = map[string]struct{}{}
+
+func init() {
+	for _, ext := range strings.Fields(`
+		.123 .602 .abw .bib .bmp .cdr .cgm .cmx .csv .cwk .dbf .dif .doc .docm
+		.docx .dot .dotm .dotx .dxf .emf .eps .epub .fodg .fodp .fods .fodt .fopd
+		.gif .htm .html .hwp .jpeg .jpg .key .ltx .lwp .mcw .met .mml .mw .numbers
+		.odd .odg .odm .odp .ods .odt .otg .oth .otp .ots .ott .pages .pbm .pcd
+		.pct .pcx .pdb .pdf .pgm .png .pot .potm .potx .ppm .pps .ppt .pptm .pptx
+		.psd .psw .pub .pwp .pxl .ras .rtf .sda .sdc .sdd .sdp .sdw .sgl .slk .smf
+		.stc .std .sti .stw .svg .svm .swf .sxc .sxd .sxg .sxi .sxm .sxw .tga .tif
+		.tiff .txt .uof .uop .uos .uot .vdx .vor .vsd .vsdm .vsdx .wb2 .wk1 .wks
+		.wmf .wpd .wpg .wps .xbm .xhtml .xls .xlsb .xlsm .xlsx .xlt .xltm .xltx
+		.xlw .xml .xpm .zabw`) {
+		officeExtensions[ext] = struct{}{}
+	}
+}
+
+// OfficeExtensions returns the sorted extensions
+// of the documents LibreOffice can convert.
+func OfficeExtensions() []string {
+	exts := make([]string, 0, len(officeExtensions))
+	for ext := range officeExtensions {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	return exts
+}
+
+// IsOfficeExtension reports whether LibreOffice can convert
+// a document with the given extension, e.g. ".docx".
+func IsOfficeExtension(ext string) bool {
+	_, ok := officeExtensions[strings.ToLower(ext)]
+	return ok
+}
+
+// sniffSize is how many bytes of a document
+// are read to detect its format.
+const sniffSize = 64 << 10
+
+// officeFormat is a family of document formats
+// sharing the same signature.
+type officeFormat struct {
+	// ext is the extension given to mislabeled documents.
+	ext string
+	// exts are the extensions consistent with the format.
+	exts []string
+}
+
+func (f officeFormat) accepts(ext string) bool {
+	ext = strings.ToLower(ext)
+	for _, e := range f.exts {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// nolint:gochecknoglobals
+var (
+	ole2Signature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+	zipSignature  = []byte("PK\x03\x04")
+	rtfSignature  = []byte(`{\rtf`)
+	pdfSignature  = []byte("%PDF-")
+
+	ole2Word       = officeFormat{".doc", []string{".doc", ".dot", ".wps"}}
+	ole2Excel      = officeFormat{".xls", []string{".xls", ".xlt", ".xlw"}}
+	ole2PowerPoint = officeFormat{".ppt", []string{".ppt", ".pps", ".pot"}}
+	ooxmlWord      = officeFormat{".docx", []string{".docx", ".docm", ".dotx", ".dotm"}}
+	ooxmlExcel     = officeFormat{".xlsx", []string{".xlsx", ".xlsm", ".xltx", ".xltm", ".xlsb"}}
+	ooxmlPowerPt   = officeFormat{".pptx", []string{".pptx", ".pptm", ".potx", ".potm"}}
+	ooxmlVisio     = officeFormat{".vsdx", []string{".vsdx", ".vsdm"}}
+	rtfDocument    = officeFormat{".rtf", []string{".rtf", ".doc"}}
+	pdfDocument    = officeFormat{".pdf", []string{".pdf"}}
+
+	odfMimeTypes = map[string]officeFormat{
+		"application/vnd.oasis.opendocument.text":                  {".odt", []string{".odt", ".ott", ".odm", ".oth"}},
+		"application/vnd.oasis.opendocument.text-template":         {".ott", []string{".ott", ".odt"}},
+		"application/vnd.oasis.opendocument.text-master":           {".odm", []string{".odm", ".odt"}},
+		"application/vnd.oasis.opendocument.spreadsheet":           {".ods", []string{".ods", ".ots"}},
+		"application/vnd.oasis.opendocument.spreadsheet-template":  {".ots", []string{".ots", ".ods"}},
+		"application/vnd.oasis.opendocument.presentation":          {".odp", []string{".odp", ".otp"}},
+		"application/vnd.oasis.opendocument.presentation-template": {".otp", []string{".otp", ".odp"}},
+		"application/vnd.oasis.opendocument.graphics":              {".odg", []string{".odg", ".otg", ".odd"}},
+		"application/vnd.oasis.opendocument.graphics-template":     {".otg", []string{".otg", ".odg"}},
+	}
+)
+
+// sniffOfficeFormat detects the format of a document from its first
+// bytes. It returns false if the format is unknown or ambiguous.
+func sniffOfficeFormat(data []byte) (officeFormat, bool) {
+	switch {
+	case bytes.HasPrefix(data, ole2Signature):
+		offset, size, ok := ole2Directory(data)
+		if ok && offset+int64(size) <= int64(len(data)) {
+			return sniffOLE2Directory(data[offset : offset+int64(size)])
+		}
+		return sniffOLE2Directory(data)
+	case bytes.HasPrefix(data, zipSignature):
+		// ODF documents start with an uncompressed mimetype file.
+		if len(data) > 38 && string(data[30:38]) == "mimetype" {
+			end := bytes.Index(data[38:], zipSignature)
+			if end < 0 {
+				end = len(data) - 38
+			}
+			if format, ok := odfMimeTypes[string(data[38:38+end])]; ok {
+				return format, true
+			}
+		}
+		switch {
+		case bytes.Contains(data, []byte("word/")):
+			return ooxmlWord, true
+		case bytes.Contains(data, []byte("xl/")):
+			return ooxmlExcel, true
+		case bytes.Contains(data, []byte("ppt/")):
+			return ooxmlPowerPt, true
+		case bytes.Contains(data, []byte("visio/")):
+			return ooxmlVisio, true
+		}
+	case bytes.HasPrefix(data, rtfSignature):
+		return rtfDocument, true
+	case bytes.HasPrefix(data, pdfSignature):
+		return pdfDocument, true
+	}
+	return officeFormat{}, false
+}
+
+// ole2Directory returns the offset and the size of the first
+// directory sector of an OLE2 file, read from its header.
+func ole2Directory(header []byte) (int64, int, bool) {
+	if len(header) < 52 {
+		return 0, 0, false
+	}
+	shift := binary.LittleEndian.Uint16(header[30:32])
+	if shift != 9 && shift != 12 {
+		return 0, 0, false
+	}
+	sector := binary.LittleEndian.Uint32(header[48:52])
+	if sector >= 0xFFFFFFFA {
+		return 0, 0, false
+	}
+	size := 1 << shift
+	// Sectors are numbered from the end of the header,
+	// which spans one sector.
+	return int64(sector+1) * int64(size), size, true
+}
+
+// sniffOLE2Directory detects the format of an OLE2 file from the
+// names of its streams, stored in UTF-16LE in its directory.
+func sniffOLE2Directory(dir []byte) (officeFormat, bool) {
+	switch {
+	case bytes.Contains(dir, utf16le("WordDocument")):
+		return ole2Word, true
+	case bytes.Contains(dir, utf16le("Workbook")), bytes.Contains(dir, utf16le("Book")):
+		return ole2Excel, true
+	case bytes.Contains(dir, utf16le("PowerPoint Document")):
+		return ole2PowerPoint, true
+	default:
+		return officeFormat{}, false
+	}
+}
+
+// readOLE2Directory reads the first directory sector of an OLE2
+// document, stored after its first bytes given in head.
+func readOLE2Directory(doc Document, head []byte) ([]byte, error) {
+	offset, size, ok := ole2Directory(head)
+	if !ok || offset+int64(size) <= int64(len(head)) {
+		return nil, nil
+	}
+	in, err := doc.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close() // nolint: errcheck
+	if _, err := io.CopyN(io.Discard, in, offset); err != nil {
+		return nil, nil
+	}
+	dir := make([]byte, size)
+	if _, err := io.ReadFull(in, dir); err != nil {
+		return nil, nil
+	}
+	return dir, nil
+}
+
+func utf16le(s string) []byte {
+	b := make([]byte, 0, 2*len(s))
+	for i := 0; i < len(s); i++ {
+		b = append(b, s[i], 0)
+	}
+	return b
+}
+
+type documentWithFilename struct {
+	filename string
+
+	Document
+}
+
+func (doc *documentWithFilename) Filename() string {
+	return doc.filename
+}
+
+// SniffOfficeDocument detects the format of a document from its content
+// and, if its filename extension is inconsistent with it, returns the
+// document with a fixed filename. Otherwise, including when the format
+// cannot be determined, it returns a document equivalent to the given one.
+//
+// Single-use documents, e.g. created from an io.Reader, must not
+// be used afterwards: use the returned document instead.
+func SniffOfficeDocument(doc Document) (Document, error) {
+	in, err := doc.Reader()
+	if err != nil {
+		return nil, err
+	}
+	head := make([]byte, sniffSize)
+	n, err := io.ReadFull(in, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		in.Close() // nolint: errcheck
+		return nil, err
+	}
+	head = head[:n]
+	if isSingleUse(doc) {
+		// The reader cannot be opened again: chain what has
+		// been read with what remains.
+		return NewDocumentFromReader(sniffedFilename(doc.Filename(), head, nil), struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(head), in), in})
+	}
+	if err := in.Close(); err != nil {
+		return nil, err
+	}
+	var dir []byte
+	if bytes.HasPrefix(head, ole2Signature) {
+		// The directory may be stored past the first bytes.
+		if dir, err = readOLE2Directory(doc, head); err != nil {
+			return nil, err
+		}
+	}
+	filename := sniffedFilename(doc.Filename(), head, dir)
+	if filename == doc.Filename() {
+		return doc, nil
+	}
+	return &documentWithFilename{filename, doc}, nil
+}
+
+// sniffedFilename returns filename with the extension of the format
+// detected from the first bytes of a document, or from the directory
+// of an OLE2 document, if it is inconsistent with it.
+func sniffedFilename(filename string, head, dir []byte) string {
+	format, ok := sniffOfficeFormat(head)
+	if !ok && dir != nil {
+		format, ok = sniffOLE2Directory(dir)
+	}
+	if !ok || format.accepts(filepath.Ext(filename)) {
+		return filename
+	}
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + format.ext
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ Document = new(documentWithFilename)
+)
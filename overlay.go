@@ -0,0 +1,194 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Embeds
+const (
+	embeds string = "embeds" // Files to embed as attachments of the resulting PDF
+)
+
+// Watermark and stamp
+const (
+	watermark        string = "watermark"  // The image or PDF file of the watermark
+	stamp            string = "stamp"      // The image or PDF file of the stamp
+	sourceSuffix     string = "Source"     // With watermark or stamp, the kind of overlay: text, image or pdf
+	expressionSuffix string = "Expression" // With watermark or stamp, the text or the filename of the overlay
+	pagesSuffix      string = "Pages"      // With watermark or stamp, the pages to apply the overlay to
+	optionsSuffix    string = "Options"    // With watermark or stamp, the JSON options of the PDF engine
+)
+
+// OverlaySource is the kind of content of a watermark or a stamp.
+type OverlaySource string
+
+// Overlay sources.
+const (
+	OverlaySourceText  OverlaySource = "text"
+	OverlaySourceImage OverlaySource = "image"
+	OverlaySourcePDF   OverlaySource = "pdf"
+)
+
+// OverlayPosition is the anchor of a watermark
+// or a stamp on the page.
+type OverlayPosition string
+
+// Overlay positions.
+const (
+	PositionCenter       OverlayPosition = "c"
+	PositionTopLeft      OverlayPosition = "tl"
+	PositionTopCenter    OverlayPosition = "tc"
+	PositionTopRight     OverlayPosition = "tr"
+	PositionLeft         OverlayPosition = "l"
+	PositionRight        OverlayPosition = "r"
+	PositionBottomLeft   OverlayPosition = "bl"
+	PositionBottomCenter OverlayPosition = "bc"
+	PositionBottomRight  OverlayPosition = "br"
+)
+
+// Overlay is a watermark, drawn behind the content of
+// the pages, or a stamp, drawn on top of it.
+// Create it with TextOverlay, ImageOverlay or PDFOverlay.
+type Overlay struct {
+	Source OverlaySource
+	// Expression is the text of a text overlay, or the
+	// filename of File for image and PDF overlays.
+	Expression string
+	// File is the image or PDF of the overlay.
+	File Document
+	// Pages are the pages to apply the overlay to.
+	// Nil means all pages.
+	Pages *PageRanges
+	// Opacity is from 0 (exclusive) to 1. Zero means
+	// the default of the PDF engine.
+	Opacity float64
+	// Rotation is in degrees, from -180 to 180.
+	Rotation float64
+	// Position is the anchor on the page. Empty
+	// means the default of the PDF engine.
+	Position OverlayPosition
+	// Options holds any other option of the PDF engine,
+	// e.g. "font". The fields above take precedence over it.
+	Options map[string]interface{}
+}
+
+// TextOverlay creates an Overlay drawing text.
+func TextOverlay(text string) Overlay {
+	return Overlay{Source: OverlaySourceText, Expression: text}
+}
+
+// ImageOverlay creates an Overlay drawing an image.
+func ImageOverlay(img Document) Overlay {
+	return fileOverlay(OverlaySourceImage, img)
+}
+
+// PDFOverlay creates an Overlay drawing the pages of a PDF.
+func PDFOverlay(pdf Document) Overlay {
+	return fileOverlay(OverlaySourcePDF, pdf)
+}
+
+func fileOverlay(source OverlaySource, doc Document) Overlay {
+	o := Overlay{Source: source, File: doc}
+	if doc != nil {
+		o.Expression = doc.Filename()
+	}
+	return o
+}
+
+func (o Overlay) validate() error {
+	switch o.Source {
+	case OverlaySourceText:
+		if o.Expression == "" {
+			return fmt.Errorf("text is empty")
+		}
+	case OverlaySourceImage, OverlaySourcePDF:
+		if o.File == nil {
+			return fmt.Errorf("%s source without a file", o.Source)
+		}
+	default:
+		return fmt.Errorf("%q: invalid source", o.Source)
+	}
+	if o.Opacity < 0 || o.Opacity > 1 {
+		return fmt.Errorf("%g: opacity must be between 0 and 1", o.Opacity)
+	}
+	if o.Rotation < -180 || o.Rotation > 180 {
+		return fmt.Errorf("%g: rotation must be between -180 and 180", o.Rotation)
+	}
+	switch o.Position {
+	case "", PositionCenter, PositionTopLeft, PositionTopCenter, PositionTopRight, PositionLeft,
+		PositionRight, PositionBottomLeft, PositionBottomCenter, PositionBottomRight:
+	default:
+		return fmt.Errorf("%q: invalid position", o.Position)
+	}
+	return nil
+}
+
+// options serializes the options as expected by the PDF engine.
+func (o Overlay) options() (string, error) {
+	options := make(map[string]interface{})
+	for key, value := range o.Options {
+		options[key] = value
+	}
+	if o.Opacity != 0 {
+		options["opacity"] = o.Opacity
+	}
+	if o.Rotation != 0 {
+		options["rotation"] = o.Rotation
+	}
+	if o.Position != "" {
+		options["position"] = string(o.Position)
+	}
+	if len(options) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(options)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Embed adds files to embed as attachments of the resulting PDF.
+func (req *request) Embed(docs ...Document) {
+	req.fieldFiles[embeds] = append(req.fieldFiles[embeds], docs...)
+}
+
+// Watermark sets the watermark form fields, which draw
+// an overlay behind the content of the resulting PDF.
+func (req *request) Watermark(o Overlay) error {
+	return req.overlay(watermark, o)
+}
+
+// Stamp sets the stamp form fields, which draw an
+// overlay on top of the content of the resulting PDF.
+func (req *request) Stamp(o Overlay) error {
+	return req.overlay(stamp, o)
+}
+
+func (req *request) overlay(field string, o Overlay) error {
+	if err := o.validate(); err != nil {
+		return fmt.Errorf("%s: %v", field, err)
+	}
+	options, err := o.options()
+	if err != nil {
+		return fmt.Errorf("%s: %v", field, err)
+	}
+
+	req.values[field+sourceSuffix] = string(o.Source)
+	req.values[field+expressionSuffix] = o.Expression
+	delete(req.values, field+pagesSuffix)
+	if o.Pages != nil {
+		req.values[field+pagesSuffix] = o.Pages.String()
+	}
+	delete(req.values, field+optionsSuffix)
+	if options != "" {
+		req.values[field+optionsSuffix] = options
+	}
+	delete(req.fieldFiles, field)
+	if o.File != nil {
+		req.fieldFiles[field] = []Document{o.File}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package gotenberg
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Unit is a length unit accepted by Gotenberg.
+type Unit string
+
+// Length units.
+const (
+	Inch       Unit = "in"
+	Millimeter Unit = "mm"
+	Centimeter Unit = "cm"
+	Point      Unit = "pt"
+	Pixel      Unit = "px"
+	Pica       Unit = "pc"
+)
+
+// unitsPerInch is the number of units in one inch.
+var unitsPerInch = map[Unit]float64{
+	Inch:       1,
+	Millimeter: 25.4,
+	Centimeter: 2.54,
+	Point:      72,
+	Pixel:      96,
+	Pica:       6,
+}
+
+// Length is a length with its unit.
+type Length struct {
+	Value float64
+	Unit  Unit
+}
+
+// Inches creates a Length in inches.
+func Inches(value float64) Length {
+	return Length{value, Inch}
+}
+
+// Millimeters creates a Length in millimeters.
+func Millimeters(value float64) Length {
+	return Length{value, Millimeter}
+}
+
+// Centimeters creates a Length in centimeters.
+func Centimeters(value float64) Length {
+	return Length{value, Centimeter}
+}
+
+// Points creates a Length in points.
+func Points(value float64) Length {
+	return Length{value, Point}
+}
+
+// Pixels creates a Length in CSS pixels.
+func Pixels(value float64) Length {
+	return Length{value, Pixel}
+}
+
+// Picas creates a Length in picas.
+func Picas(value float64) Length {
+	return Length{value, Pica}
+}
+
+// ParseLength parses a length such as "210mm" or "8.5in".
+// A length without unit is in inches.
+func ParseLength(s string) (Length, error) {
+	s = strings.TrimSpace(s)
+	unit := Inch
+	for u := range unitsPerInch {
+		if strings.HasSuffix(s, string(u)) {
+			unit = u
+			s = strings.TrimSpace(strings.TrimSuffix(s, string(u)))
+			break
+		}
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return Length{}, fmt.Errorf("%q: invalid length", s)
+	}
+	return Length{value, unit}, nil
+}
+
+// Inches returns the length converted to inches.
+func (l Length) Inches() float64 {
+	perInch, ok := unitsPerInch[l.Unit]
+	if !ok {
+		perInch = 1
+	}
+	return l.Value / perInch
+}
+
+// String returns the length as expected by Gotenberg, e.g. "210mm".
+func (l Length) String() string {
+	return strconv.FormatFloat(l.Value, 'f', -1, 64) + string(l.Unit)
+}
+
+// PageSize is a paper size.
+type PageSize struct {
+	Width  Length
+	Height Length
+}
+
+// Portrait returns the page size with its
+// height greater than or equal to its width.
+func (s PageSize) Portrait() PageSize {
+	if s.Width.Inches() > s.Height.Inches() {
+		return PageSize{s.Height, s.Width}
+	}
+	return s
+}
+
+// Landscape returns the page size with its
+// width greater than or equal to its height.
+func (s PageSize) Landscape() PageSize {
+	if s.Height.Inches() > s.Width.Inches() {
+		return PageSize{s.Height, s.Width}
+	}
+	return s
+}
+
+// PageMargins are the margins of a page.
+type PageMargins struct {
+	Top    Length
+	Bottom Length
+	Left   Length
+	Right  Length
+}
+
+// UniformMargins creates PageMargins with the same length on every side.
+func UniformMargins(l Length) PageMargins {
+	return PageMargins{l, l, l, l}
+}
+
+// Page sizes. Use Portrait or Landscape to change their orientation.
+// nolint:gochecknoglobals
+var (
+	// ISO A series.
+	PageA0 = PageSize{Millimeters(841), Millimeters(1189)}
+	PageA1 = PageSize{Millimeters(594), Millimeters(841)}
+	PageA2 = PageSize{Millimeters(420), Millimeters(594)}
+	PageA3 = PageSize{Millimeters(297), Millimeters(420)}
+	PageA4 = PageSize{Millimeters(210), Millimeters(297)}
+	PageA5 = PageSize{Millimeters(148), Millimeters(210)}
+	PageA6 = PageSize{Millimeters(105), Millimeters(148)}
+
+	// ISO B series.
+	PageB0 = PageSize{Millimeters(1000), Millimeters(1414)}
+	PageB1 = PageSize{Millimeters(707), Millimeters(1000)}
+	PageB2 = PageSize{Millimeters(500), Millimeters(707)}
+	PageB3 = PageSize{Millimeters(353), Millimeters(500)}
+	PageB4 = PageSize{Millimeters(250), Millimeters(353)}
+	PageB5 = PageSize{Millimeters(176), Millimeters(250)}
+	PageB6 = PageSize{Millimeters(125), Millimeters(176)}
+
+	// Envelopes.
+	PageEnvelopeDL = PageSize{Millimeters(110), Millimeters(220)}
+	PageEnvelopeC4 = PageSize{Millimeters(229), Millimeters(324)}
+	PageEnvelopeC5 = PageSize{Millimeters(162), Millimeters(229)}
+	PageEnvelopeC6 = PageSize{Millimeters(114), Millimeters(162)}
+	PageEnvelope10 = PageSize{Inches(4.125), Inches(9.5)}
+
+	// North American sizes.
+	PageLetter           = PageSize{Inches(8.5), Inches(11)}
+	PageLegal            = PageSize{Inches(8.5), Inches(14)}
+	PageTabloid          = PageSize{Inches(11), Inches(17)}
+	PageLedger           = PageTabloid.Landscape()
+	PageExecutive        = PageSize{Inches(7.25), Inches(10.5)}
+	PageStatement        = PageSize{Inches(5.5), Inches(8.5)}
+	PageJuniorLegal      = PageSize{Inches(5), Inches(8)}
+	PageGovernmentLetter = PageSize{Inches(8), Inches(10.5)}
+)
@@ -0,0 +1,38 @@
+package gotenberg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLength(t *testing.T) {
+	assert.Equal(t, "210mm", Millimeters(210).String())
+	assert.Equal(t, "8.5in", Inches(8.5).String())
+	assert.InDelta(t, 1, Points(72).Inches(), 1e-9)
+	assert.InDelta(t, 1, Millimeters(25.4).Inches(), 1e-9)
+	l, err := ParseLength("2.5cm")
+	require.Nil(t, err)
+	assert.Equal(t, Centimeters(2.5), l)
+	l, err = ParseLength("0.39")
+	require.Nil(t, err)
+	assert.Equal(t, Inches(0.39), l)
+	_, err = ParseLength("banana")
+	assert.NotNil(t, err)
+}
+
+func TestPageSize(t *testing.T) {
+	assert.Equal(t, PageSize{Millimeters(297), Millimeters(210)}, PageA4.Landscape())
+	assert.Equal(t, PageA4, PageA4.Landscape().Portrait())
+	assert.Equal(t, PageA4, PageA4.Portrait())
+	assert.Equal(t, PageSize{Inches(17), Inches(11)}, PageLedger)
+
+	req := NewConvertURLRequest("https://example.com")
+	req.PageSize(PageB5.Landscape())
+	req.PageMargins(PageMargins{Top: Millimeters(10), Bottom: Millimeters(10), Left: Points(36), Right: Points(36)})
+	assert.Equal(t, "250mm", req.formValues()[paperWidth])
+	assert.Equal(t, "176mm", req.formValues()[paperHeight])
+	assert.Equal(t, "10mm", req.formValues()[marginTop])
+	assert.Equal(t, "36pt", req.formValues()[marginRight])
+}
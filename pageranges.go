@@ -0,0 +1,162 @@
+package gotenberg
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PageRanges is a set of pages to convert,
+// kept sorted and without overlaps.
+type PageRanges struct {
+	ranges []pageRange
+}
+
+type pageRange struct {
+	from, to int
+}
+
+// ParsePageRanges parses page ranges such as "1-5, 8, 11-13".
+func ParsePageRanges(s string) (*PageRanges, error) {
+	r := &PageRanges{}
+	if err := r.Parse(s); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Parse adds page ranges such as "1-5, 8, 11-13".
+// Nothing is added if the syntax is invalid.
+func (r *PageRanges) Parse(s string) error {
+	var ranges []pageRange
+	for _, item := range strings.Split(s, ",") {
+		bounds := strings.SplitN(item, "-", 2)
+		from, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil {
+			return fmt.Errorf("%q: invalid page range %q", s, strings.TrimSpace(item))
+		}
+		to := from
+		if len(bounds) == 2 {
+			to, err = strconv.Atoi(strings.TrimSpace(bounds[1]))
+			if err != nil {
+				return fmt.Errorf("%q: invalid page range %q", s, strings.TrimSpace(item))
+			}
+		}
+		if err := validatePageRange(from, to); err != nil {
+			return fmt.Errorf("%q: %v", s, err)
+		}
+		ranges = append(ranges, pageRange{from, to})
+	}
+	for _, pr := range ranges {
+		r.add(pr)
+	}
+	return nil
+}
+
+// Add adds the pages from and to included.
+func (r *PageRanges) Add(from, to int) error {
+	if err := validatePageRange(from, to); err != nil {
+		return err
+	}
+	r.add(pageRange{from, to})
+	return nil
+}
+
+// Single adds a single page.
+func (r *PageRanges) Single(n int) error {
+	return r.Add(n, n)
+}
+
+// First adds the first n pages.
+func (r *PageRanges) First(n int) error {
+	return r.Add(1, n)
+}
+
+// Last adds the last n pages of a document of total pages.
+func (r *PageRanges) Last(n, total int) error {
+	if n > total {
+		n = total
+	}
+	return r.Add(total-n+1, total)
+}
+
+// Odd adds the odd pages of a document of total pages.
+func (r *PageRanges) Odd(total int) error {
+	return r.every(1, total)
+}
+
+// Even adds the even pages of a document of total pages.
+func (r *PageRanges) Even(total int) error {
+	return r.every(2, total)
+}
+
+func (r *PageRanges) every(first, total int) error {
+	if total < first {
+		return fmt.Errorf("%d: not enough pages", total)
+	}
+	for n := first; n <= total; n += 2 {
+		r.add(pageRange{n, n})
+	}
+	return nil
+}
+
+// add inserts a valid range, merging it with
+// the ranges it overlaps or is adjacent to.
+func (r *PageRanges) add(pr pageRange) {
+	ranges := append(r.ranges, pr)
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].from < ranges[j].from
+	})
+	merged := ranges[:1]
+	for _, next := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if next.from <= last.to+1 {
+			if next.to > last.to {
+				last.to = next.to
+			}
+			continue
+		}
+		merged = append(merged, next)
+	}
+	r.ranges = merged
+}
+
+// String returns the page ranges, e.g. "1-5,8,11-13".
+func (r *PageRanges) String() string {
+	return r.join(",")
+}
+
+// chromiumString returns the page ranges as
+// expected by Chromium, e.g. "1-5, 8, 11-13".
+func (r *PageRanges) chromiumString() string {
+	return r.join(", ")
+}
+
+// libreOfficeString returns the page ranges as
+// expected by LibreOffice, e.g. "1-5,8,11-13".
+func (r *PageRanges) libreOfficeString() string {
+	return r.join(",")
+}
+
+func (r *PageRanges) join(sep string) string {
+	items := make([]string, len(r.ranges))
+	for i, pr := range r.ranges {
+		if pr.from == pr.to {
+			items[i] = strconv.Itoa(pr.from)
+		} else {
+			items[i] = fmt.Sprintf("%d-%d", pr.from, pr.to)
+		}
+	}
+	return strings.Join(items, sep)
+}
+
+func validatePageRange(from, to int) error {
+	if from < 1 {
+		return fmt.Errorf("%d: pages start at 1", from)
+	}
+	if to < from {
+		return fmt.Errorf("%d-%d: end before start", from, to)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package gotenberg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageRanges(t *testing.T) {
+	r, err := ParsePageRanges(" 11-13,1-5, 8 ,4-6")
+	require.Nil(t, err)
+	assert.Equal(t, "1-6,8,11-13", r.String())
+	require.Nil(t, r.Single(7))
+	assert.Equal(t, "1-8,11-13", r.String())
+	for _, s := range []string{"banana", "", "1-", "0-2", "3-1", "1,,2"} {
+		_, err = ParsePageRanges(s)
+		assert.NotNil(t, err, s)
+	}
+
+	r = &PageRanges{}
+	require.Nil(t, r.First(2))
+	require.Nil(t, r.Last(3, 10))
+	assert.Equal(t, "1-2,8-10", r.String())
+	r = &PageRanges{}
+	require.Nil(t, r.Odd(7))
+	assert.Equal(t, "1,3,5,7", r.String())
+	assert.NotNil(t, r.Add(0, 1))
+	assert.NotNil(t, r.Add(3, 2))
+
+	req := NewConvertURLRequest("https://example.com")
+	req.Pages(r)
+	assert.Equal(t, "1, 3, 5, 7", req.formValues()[nativePageRanges])
+	office := NewOfficeRequest()
+	office.Pages(r)
+	assert.Equal(t, "1,3,5,7", office.formValues()[nativePageRangesOffice])
+	req.Pages(nil)
+	assert.NotContains(t, req.formValues(), nativePageRanges)
+	office.Pages(nil)
+	assert.NotContains(t, office.formValues(), nativePageRangesOffice)
+}
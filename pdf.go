@@ -0,0 +1,44 @@
+package gotenberg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PDFFormat is a PDF/A format of the resulting PDF.
+// PDF/UA is enabled separately, with the PDFUA methods.
+type PDFFormat string
+
+// PDF/A formats. PDF/A-1a is supported by Gotenberg 7
+// only, and PDF/A-1b by Gotenberg 8 only.
+const (
+	PDFA1a PDFFormat = "PDF/A-1a"
+	PDFA1b PDFFormat = "PDF/A-1b"
+	PDFA2b PDFFormat = "PDF/A-2b"
+	PDFA3b PDFFormat = "PDF/A-3b"
+)
+
+// pdfFormats lists the PDF formats accepted by each version.
+// Every route of a version accepts the same formats.
+// nolint:gochecknoglobals
+var pdfFormats = map[Version][]PDFFormat{
+	Gotenberg7: {PDFA1a, PDFA2b, PDFA3b},
+	Gotenberg8: {PDFA1b, PDFA2b, PDFA3b},
+}
+
+// validatePDFFormat checks that a value is empty or
+// one of the formats accepted by the given version.
+func validatePDFFormat(value string, version Version) error {
+	if value == "" {
+		return nil
+	}
+	accepted := pdfFormats[version]
+	names := make([]string, len(accepted))
+	for i, format := range accepted {
+		if PDFFormat(value) == format {
+			return nil
+		}
+		names[i] = string(format)
+	}
+	return fmt.Errorf("unsupported PDF format %q by Gotenberg %d, expected one of %s", value, version, strings.Join(names, ", "))
+}
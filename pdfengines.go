@@ -0,0 +1,112 @@
+package gotenberg
+
+// FlattenRequest facilitates flattening the form
+// fields of PDF with the Gotenberg API.
+type FlattenRequest struct {
+	pdfs []Document
+
+	*request
+}
+
+// NewFlattenRequest create FlattenRequest.
+func NewFlattenRequest(pdfs ...Document) *FlattenRequest {
+	return &FlattenRequest{pdfs, newRequest()}
+}
+
+// Validate checks the form fields and the documents of the request.
+func (req *FlattenRequest) Validate() error {
+	v := &validation{}
+	req.request.validate(v)
+	req.since(v, flattenMerge, Gotenberg8)
+	v.checkPDFs("files", req.pdfs, req.hasDownloads())
+	return v.err()
+}
+
+func (req *FlattenRequest) postURL() string {
+	return "/forms/pdfengines/flatten"
+}
+
+func (req *FlattenRequest) formFiles() map[string]Document {
+	return pdfFiles(req.pdfs)
+}
+
+// WatermarkRequest facilitates drawing a watermark
+// on PDF with the Gotenberg API. The watermark is
+// set with the Watermark method.
+type WatermarkRequest struct {
+	pdfs []Document
+
+	*request
+}
+
+// NewWatermarkRequest create WatermarkRequest.
+func NewWatermarkRequest(pdfs ...Document) *WatermarkRequest {
+	return &WatermarkRequest{pdfs, newRequest()}
+}
+
+// Validate checks the form fields and the documents of the request.
+func (req *WatermarkRequest) Validate() error {
+	v := &validation{}
+	req.request.validate(v)
+	v.checkPDFs("files", req.pdfs, req.hasDownloads())
+	if req.values[watermark+sourceSuffix] == "" {
+		v.addf(watermark+sourceSuffix, "a watermark is required")
+	}
+	return v.err()
+}
+
+func (req *WatermarkRequest) postURL() string {
+	return "/forms/pdfengines/watermark"
+}
+
+func (req *WatermarkRequest) formFiles() map[string]Document {
+	return pdfFiles(req.pdfs)
+}
+
+// StampRequest facilitates drawing a stamp
+// on PDF with the Gotenberg API. The stamp
+// is set with the Stamp method.
+type StampRequest struct {
+	pdfs []Document
+
+	*request
+}
+
+// NewStampRequest create StampRequest.
+func NewStampRequest(pdfs ...Document) *StampRequest {
+	return &StampRequest{pdfs, newRequest()}
+}
+
+// Validate checks the form fields and the documents of the request.
+func (req *StampRequest) Validate() error {
+	v := &validation{}
+	req.request.validate(v)
+	v.checkPDFs("files", req.pdfs, req.hasDownloads())
+	if req.values[stamp+sourceSuffix] == "" {
+		v.addf(stamp+sourceSuffix, "a stamp is required")
+	}
+	return v.err()
+}
+
+func (req *StampRequest) postURL() string {
+	return "/forms/pdfengines/stamp"
+}
+
+func (req *StampRequest) formFiles() map[string]Document {
+	return pdfFiles(req.pdfs)
+}
+
+func pdfFiles(pdfs []Document) map[string]Document {
+	files := make(map[string]Document)
+	for _, pdf := range pdfs {
+		files[pdf.Filename()] = pdf
+	}
+	return files
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Request(new(FlattenRequest))
+	_ = Request(new(WatermarkRequest))
+	_ = Request(new(StampRequest))
+)
@@ -0,0 +1,77 @@
+package gotenberg
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"mime"
+	"mime/multipart"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	pdf, err := NewDocumentFromString("form.pdf", "%PDF-1.7")
+	require.Nil(t, err)
+	req := NewFlattenRequest(pdf)
+	assert.Nil(t, req.Validate())
+	assert.Equal(t, "/forms/pdfengines/flatten", req.postURL())
+	assert.NotNil(t, NewFlattenRequest().Validate())
+}
+
+func TestWatermark(t *testing.T) {
+	pdf, err := NewDocumentFromString("report.pdf", "%PDF-1.7")
+	require.Nil(t, err)
+	req := NewWatermarkRequest(pdf)
+	assert.NotNil(t, req.Validate())
+	pages, err := ParsePageRanges("1-3")
+	require.Nil(t, err)
+	o := TextOverlay("CONFIDENTIAL")
+	o.Pages = pages
+	o.Opacity = 0.25
+	o.Rotation = 45
+	o.Position = PositionCenter
+	require.Nil(t, req.Watermark(o))
+	assert.Nil(t, req.Validate())
+	values := req.formValues()
+	assert.Equal(t, "text", values["watermarkSource"])
+	assert.Equal(t, "CONFIDENTIAL", values["watermarkExpression"])
+	assert.Equal(t, "1-3", values["watermarkPages"])
+	assert.JSONEq(t, `{"opacity":0.25,"rotation":45,"position":"c"}`, values["watermarkOptions"])
+	o.Opacity = 2
+	assert.NotNil(t, req.Watermark(o))
+	assert.NotNil(t, req.Stamp(ImageOverlay(nil)))
+	assert.NotNil(t, req.Stamp(Overlay{Source: "video", Expression: "x"}))
+}
+
+func TestStampFile(t *testing.T) {
+	pdf, err := NewDocumentFromString("report.pdf", "%PDF-1.7")
+	require.Nil(t, err)
+	logo, err := NewDocumentFromString("logo.png", "png")
+	require.Nil(t, err)
+	attachment, err := NewDocumentFromString("data.csv", "a,b")
+	require.Nil(t, err)
+	req := NewStampRequest(pdf)
+	require.Nil(t, req.Stamp(ImageOverlay(logo)))
+	req.Embed(attachment)
+	assert.Nil(t, req.Validate())
+	assert.Equal(t, "image", req.formValues()["stampSource"])
+	assert.Equal(t, "logo.png", req.formValues()["stampExpression"])
+	body, contentType, err := multipartForm(req.formFiles(), req.formFieldFiles(), req.formValues())
+	require.Nil(t, err)
+	_, params, err := mime.ParseMediaType(contentType)
+	require.Nil(t, err)
+	fields := make(map[string]string)
+	reader := multipart.NewReader(bytes.NewReader(body.Bytes()), params["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.Nil(t, err)
+		if part.FileName() != "" {
+			fields[part.FileName()] = part.FormName()
+		}
+	}
+	assert.Equal(t, map[string]string{"report.pdf": "files", "logo.png": "stamp", "data.csv": "embeds"}, fields)
+}
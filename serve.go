@@ -0,0 +1,101 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+const defaultResultFilename string = "result.pdf"
+
+// Serve posts the request to the Gotenberg API and streams
+// the result into w, without buffering it. The Content-Disposition
+// header is either inline or attachment, and uses the result filename.
+//
+// If the conversion fails, Serve writes an appropriate HTTP status
+// code to w and returns the error.
+func (c *Client) Serve(ctx context.Context, w http.ResponseWriter, req Request, inline bool) error {
+	if hasWebhook(req) {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return errors.New("cannot use Serve method with a webhook")
+	}
+	resp, err := c.Post(ctx, req)
+	if err != nil {
+		// An invalid request is a mistake of the caller,
+		// not a failure of Gotenberg.
+		var vErr *ValidationError
+		status := http.StatusBadGateway
+		switch {
+		case errors.As(err, &vErr):
+			status = http.StatusInternalServerError
+		case errors.Is(err, context.DeadlineExceeded):
+			status = http.StatusGatewayTimeout
+		}
+		http.Error(w, http.StatusText(status), status)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		status := serveStatusCode(resp.StatusCode)
+		http.Error(w, http.StatusText(status), status)
+		return newError(resp)
+	}
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/pdf"
+	}
+	disposition := "attachment"
+	if inline {
+		disposition = "inline"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType(disposition, map[string]string{
+		"filename": serveFilename(req, resp),
+	}))
+	if resp.ContentLength >= 0 {
+		w.Header().Set("Content-Length", strconv.FormatInt(resp.ContentLength, 10))
+	}
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("streaming result: %v", err)
+	}
+	return nil
+}
+
+// serveFilename returns the result filename set on the request,
+// the one sent back by Gotenberg or a default one.
+func serveFilename(req Request, resp *http.Response) string {
+	if filename := req.customHTTPHeaders()[resultFilename]; filename != "" {
+		return filename
+	}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		if filename := params["filename"]; filename != "" {
+			return filename
+		}
+	}
+	return defaultResultFilename
+}
+
+// serveStatusCode maps a Gotenberg error status code
+// to the status code served to the caller.
+func serveStatusCode(gotenbergStatusCode int) int {
+	switch gotenbergStatusCode {
+	case http.StatusBadRequest:
+		// Gotenberg rejected the form built by the client.
+		return http.StatusInternalServerError
+	case http.StatusConflict:
+		// The content itself could not be converted.
+		return http.StatusUnprocessableEntity
+	case http.StatusRequestEntityTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
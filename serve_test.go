@@ -0,0 +1,67 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServe(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", `attachment; filename="gotenberg.pdf"`)
+		_, _ = w.Write([]byte("%PDF-1.4"))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, nil)
+	index, err := NewDocumentFromString("index.html", "<html>Foo</html>")
+	require.Nil(t, err)
+	req := NewConvertHTMLRequest(index)
+	req.ResultFilename("foo.pdf")
+	rec := httptest.NewRecorder()
+	err = c.Serve(context.Background(), rec, req, true)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "inline; filename=foo.pdf", rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "8", rec.Header().Get("Content-Length"))
+	assert.Equal(t, "%PDF-1.4", rec.Body.String())
+}
+
+func TestServeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Chromium console exceptions", http.StatusConflict)
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, nil)
+	index, err := NewDocumentFromString("index.html", "<html>Foo</html>")
+	require.Nil(t, err)
+	req := NewConvertHTMLRequest(index)
+	rec := httptest.NewRecorder()
+	err = c.Serve(context.Background(), rec, req, false)
+	require.NotNil(t, err)
+	gErr, ok := err.(*Error)
+	require.True(t, ok)
+	assert.Equal(t, http.StatusConflict, gErr.StatusCode)
+	assert.Equal(t, "Chromium console exceptions", gErr.Message)
+	require.NotNil(t, gErr.Failure)
+	assert.Equal(t, FailureConsoleExceptions, gErr.Failure.Reason)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+}
+
+func TestServeInvalidRequest(t *testing.T) {
+	c := NewClient("http://gotenberg:3000", nil)
+	req := NewConvertURLRequest("https://example.com")
+	req.Scale(0)
+	rec := httptest.NewRecorder()
+	err := c.Serve(context.Background(), rec, req, false)
+	require.NotNil(t, err)
+	var vErr *ValidationError
+	assert.True(t, errors.As(err, &vErr))
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
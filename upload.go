@@ -0,0 +1,98 @@
+package gotenberg
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"sort"
+)
+
+// NewOfficeRequestFromHTTP creates an OfficeRequest from the
+// files uploaded with an incoming multipart HTTP request.
+// See DocumentsFromHTTP for how the files are read.
+func NewOfficeRequestFromHTTP(r *http.Request) (*OfficeRequest, error) {
+	docs, err := DocumentsFromHTTP(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewOfficeRequest(docs...), nil
+}
+
+// NewMergeRequestFromHTTP creates a MergeRequest from the
+// files uploaded with an incoming multipart HTTP request.
+// See DocumentsFromHTTP for how the files are read.
+func NewMergeRequestFromHTTP(r *http.Request) (*MergeRequest, error) {
+	docs, err := DocumentsFromHTTP(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewMergeRequest(docs...), nil
+}
+
+// DocumentsFromHTTP creates a Document for every file uploaded
+// with an incoming multipart HTTP request, whatever its form field.
+//
+// If the multipart form has already been parsed, its files are used
+// as is. Otherwise, the request body is streamed and every file is
+// kept in memory, so nothing is written to disk; wrap the body with
+// http.MaxBytesReader to bound memory usage.
+func DocumentsFromHTTP(r *http.Request) ([]Document, error) {
+	if r.MultipartForm != nil {
+		return documentsFromMultipartForm(r)
+	}
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("reading multipart form: %v", err)
+	}
+	var docs []Document
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading multipart form: %v", err)
+		}
+		if part.FileName() == "" {
+			continue
+		}
+		filename := path.Base(part.FileName())
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return nil, fmt.Errorf("%s: reading file: %v", filename, err)
+		}
+		doc, err := NewDocumentFromBytes(filename, data)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("no file in multipart form")
+	}
+	return docs, nil
+}
+
+func documentsFromMultipartForm(r *http.Request) ([]Document, error) {
+	fields := make([]string, 0, len(r.MultipartForm.File))
+	for field := range r.MultipartForm.File {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var docs []Document
+	for _, field := range fields {
+		for _, fh := range r.MultipartForm.File[field] {
+			doc, err := NewDocumentFromFileHeader(fh)
+			if err != nil {
+				return nil, err
+			}
+			docs = append(docs, doc)
+		}
+	}
+	if len(docs) == 0 {
+		return nil, errors.New("no file in multipart form")
+	}
+	return docs, nil
+}
@@ -0,0 +1,50 @@
+package gotenberg
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUploadRequest(t *testing.T) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	require.Nil(t, writer.WriteField("name", "foo"))
+	part, err := writer.CreateFormFile("files", "document.docx")
+	require.Nil(t, err)
+	_, err = part.Write([]byte("docx"))
+	require.Nil(t, err)
+	part, err = writer.CreateFormFile("other", "sheet.xlsx")
+	require.Nil(t, err)
+	_, err = part.Write([]byte("xlsx"))
+	require.Nil(t, err)
+	require.Nil(t, writer.Close())
+	r := httptest.NewRequest(http.MethodPost, "/", body)
+	r.Header.Set("Content-Type", writer.FormDataContentType())
+	return r
+}
+
+func TestDocumentsFromHTTP(t *testing.T) {
+	for _, parsed := range []bool{false, true} {
+		r := newUploadRequest(t)
+		if parsed {
+			require.Nil(t, r.ParseMultipartForm(1<<20))
+		}
+		req, err := NewOfficeRequestFromHTTP(r)
+		require.Nil(t, err)
+		files := req.formFiles()
+		require.Len(t, files, 2)
+		in, err := files["document.docx"].Reader()
+		require.Nil(t, err)
+		data, err := io.ReadAll(in)
+		require.Nil(t, err)
+		assert.Equal(t, "docx", string(data))
+		assert.Contains(t, files, "sheet.xlsx")
+	}
+}
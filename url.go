@@ -0,0 +1,51 @@
+package gotenberg
+
+import "net/url"
+
+const remoteURL string = "url" // URL of the page to convert
+
+// ConvertURLRequest facilitates converting
+// a remote page to PDF with the Gotenberg API.
+type ConvertURLRequest struct {
+	*chromiumRequest
+}
+
+// NewConvertURLRequest create ConvertURLRequest.
+func NewConvertURLRequest(pageURL string) *ConvertURLRequest {
+	req := &ConvertURLRequest{newChromiumRequest()}
+	req.values[remoteURL] = pageURL
+	return req
+}
+
+// Validate checks the form fields of the request.
+func (req *ConvertURLRequest) Validate() error {
+	v := &validation{}
+	req.chromiumRequest.validate(v)
+	if u, err := url.Parse(req.values[remoteURL]); err != nil || !u.IsAbs() {
+		v.addf(remoteURL, "%q: must be an absolute URL", req.values[remoteURL])
+	}
+	if req.hasDownloads() {
+		v.addf(downloadFrom, "the URL route does not accept files")
+	}
+	return v.err()
+}
+
+func (req *ConvertURLRequest) postURL() string {
+	return "/forms/chromium/convert/url"
+}
+
+func (req *ConvertURLRequest) formFiles() map[string]Document {
+	files := make(map[string]Document)
+	if req.header != nil {
+		files["header.html"] = req.header
+	}
+	if req.footer != nil {
+		files["footer.html"] = req.footer
+	}
+	return files
+}
+
+// Compile-time checks to ensure type implements desired interfaces.
+var (
+	_ = Request(new(ConvertURLRequest))
+)
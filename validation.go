@@ -0,0 +1,133 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validation collects the invalid form fields of a request.
+type validation struct {
+	errs []*FieldError
+}
+
+// addf records an invalid form field.
+func (v *validation) addf(field, format string, args ...interface{}) {
+	v.errs = append(v.errs, &FieldError{Field: field, Err: fmt.Errorf(format, args...)})
+}
+
+// check records err, if any, for the form field.
+func (v *validation) check(field string, err error) {
+	if err != nil {
+		v.errs = append(v.errs, &FieldError{Field: field, Err: err})
+	}
+}
+
+// err returns a ValidationError listing every
+// invalid form field, or nil if there is none.
+func (v *validation) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: v.errs}
+}
+
+// checkDocuments records every nil document.
+func (v *validation) checkDocuments(field string, docs []Document) {
+	for i, doc := range docs {
+		if doc == nil {
+			v.addf(field, "document %d is nil", i)
+		}
+	}
+}
+
+// checkPDFs records a missing PDF, unless downloaded, every
+// nil document and every document not named as a PDF.
+func (v *validation) checkPDFs(field string, pdfs []Document, downloads bool) {
+	if len(pdfs) == 0 && !downloads {
+		v.addf(field, "at least one PDF is required")
+	}
+	v.checkDocuments(field, pdfs)
+	for _, pdf := range pdfs {
+		if pdf != nil && !strings.EqualFold(filepath.Ext(pdf.Filename()), ".pdf") {
+			v.addf(field, "%s: not a PDF file", pdf.Filename())
+		}
+	}
+}
+
+// validateLength checks that a length is valid and
+// positive, or zero if allowed.
+func validateLength(value string, allowZero bool) error {
+	l, err := ParseLength(value)
+	if err != nil {
+		return err
+	}
+	if l.Value < 0 || (l.Value == 0 && !allowZero) {
+		return fmt.Errorf("%q: must be positive", value)
+	}
+	return nil
+}
+
+// validateFloat checks that a number is within [min, max].
+func validateFloat(value string, min, max float64) error {
+	f, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return fmt.Errorf("%q: invalid number", value)
+	}
+	if f < min || f > max {
+		return fmt.Errorf("%s: must be between %s and %s", value,
+			strconv.FormatFloat(min, 'f', -1, 64), strconv.FormatFloat(max, 'f', -1, 64))
+	}
+	return nil
+}
+
+// validateDuration checks that a duration is valid and not negative.
+func validateDuration(value string) error {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q: invalid duration", value)
+	}
+	if d < 0 {
+		return fmt.Errorf("%s: must not be negative", value)
+	}
+	return nil
+}
+
+// validatePageRanges checks the syntax of page ranges, e.g. "1-5, 8, 11-13".
+func validatePageRanges(value string) error {
+	_, err := ParsePageRanges(value)
+	return err
+}
+
+// validateHTTPURL checks that a URL, e.g. of a webhook, is an absolute HTTP(S) URL.
+func validateHTTPURL(value string) error {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("%q: must be an absolute HTTP(S) URL", value)
+	}
+	return nil
+}
+
+// validateWebhookMethod checks that a webhook method is accepted by Gotenberg.
+func validateWebhookMethod(value string) error {
+	switch strings.ToUpper(value) {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return nil
+	default:
+		return fmt.Errorf("%q: must be POST, PUT or PATCH", value)
+	}
+}
+
+// validateJSONObject checks that a value is a JSON object of strings.
+func validateJSONObject(value string) error {
+	var obj map[string]string
+	if err := json.Unmarshal([]byte(value), &obj); err != nil {
+		return fmt.Errorf("invalid JSON object of strings: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package gotenberg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	req := NewConvertHTMLRequest(nil)
+	req.Scale(0)
+	req.NativePageRanges("banana")
+	req.Margins([4]float64{-1, 0, 0, 0})
+	req.WebhookURL("not a url")
+	err := req.Validate()
+	require.NotNil(t, err)
+	var vErr *ValidationError
+	require.True(t, errors.As(err, &vErr))
+	fields := make([]string, len(vErr.Errors))
+	for i, fErr := range vErr.Errors {
+		fields[i] = fErr.Field
+	}
+	assert.Equal(t, []string{webhookURL, marginTop, scale, nativePageRanges, "index.html"}, fields)
+	_, err = NewClient("http://localhost:3000", nil).Post(context.Background(), req)
+	assert.True(t, errors.As(err, &vErr))
+
+	index, err := NewDocumentFromString("index.html", "<html>Foo</html>")
+	require.Nil(t, err)
+	req = NewConvertHTMLRequest(index)
+	req.PaperSize(A4)
+	req.Margins(NormalMargins)
+	req.Scale(1.5)
+	req.NativePageRanges("1-5, 8, 11-13")
+	req.WaitDelay(1)
+	req.WebhookURL("https://example.com")
+	req.WebhookMethod("PUT")
+	assert.Nil(t, req.Validate())
+	req.NativePageRanges("5-1")
+	assert.NotNil(t, req.Validate())
+
+	assert.NotNil(t, NewOfficeRequest().Validate())
+	assert.NotNil(t, NewConvertURLRequest("example.com").Validate())
+	doc, err := NewDocumentFromString("document.docx", "foo")
+	require.Nil(t, err)
+	assert.NotNil(t, NewMergeRequest(doc).Validate())
+}
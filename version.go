@@ -0,0 +1,70 @@
+package gotenberg
+
+import "strconv"
+
+// Version is a major version of the Gotenberg API,
+// which some form fields and values depend on.
+type Version int
+
+// Gotenberg versions.
+const (
+	Gotenberg7 Version = 7
+	Gotenberg8 Version = 8
+)
+
+// DefaultVersion is the version targeted when none is set,
+// e.g. by a Client with a zero Version.
+const DefaultVersion = Gotenberg7
+
+// pdfFormatV8 replaces pdfFormat from Gotenberg 8.
+const pdfFormatV8 string = "pdfa" // The PDF/A format of the resulting PDF
+
+// target sets the Gotenberg version the request is validated against.
+func (req *request) target(version Version) {
+	req.version = version
+}
+
+func (req *request) targetVersion() Version {
+	if req.version == 0 {
+		return DefaultVersion
+	}
+	return req.version
+}
+
+// since records field, which is set, as invalid if the
+// request targets a version older than the given one.
+func (req *request) since(v *validation, field string, version Version) {
+	if target := req.targetVersion(); target < version {
+		v.addf(field, "not supported by Gotenberg %d", target)
+	}
+}
+
+func (c *Client) version() Version {
+	if c.Version == 0 {
+		return DefaultVersion
+	}
+	return c.Version
+}
+
+// versionFormValues renames the form fields of values
+// which differ in the given version. Requests always
+// store them under their Gotenberg 7 name. Lengths are
+// converted to inches for Gotenberg 7, which has no units.
+func versionFormValues(values map[string]string, version Version) {
+	if version < Gotenberg8 {
+		for _, field := range []string{paperWidth, paperHeight, marginTop, marginBottom, marginLeft, marginRight} {
+			value, ok := values[field]
+			if _, err := strconv.ParseFloat(value, 64); !ok || err == nil {
+				continue
+			}
+			if l, err := ParseLength(value); err == nil {
+				values[field] = strconv.FormatFloat(l.Inches(), 'f', -1, 64)
+			}
+		}
+		return
+	}
+	if value, ok := values[pdfFormat]; ok {
+		delete(values, pdfFormat)
+		values[pdfFormatV8] = value
+	}
+}
@@ -0,0 +1,76 @@
+package gotenberg
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionFormValues(t *testing.T) {
+	req := NewConvertURLRequest("https://example.com")
+	req.PDFFormat(PDFA2b)
+	values, err := (&Client{}).formValues(req)
+	require.Nil(t, err)
+	assert.Equal(t, "PDF/A-2b", values["pdfFormat"])
+	assert.NotContains(t, values, "pdfa")
+	values, err = (&Client{Version: Gotenberg8}).formValues(req)
+	require.Nil(t, err)
+	assert.Equal(t, "PDF/A-2b", values["pdfa"])
+	assert.NotContains(t, values, "pdfFormat")
+
+	req.PageSize(PageA4)
+	req.Margins([4]float64{1, 1, 0.5, 0.5})
+	values, err = (&Client{}).formValues(req)
+	require.Nil(t, err)
+	assert.Equal(t, "8.267716535433072", values["paperWidth"])
+	assert.Equal(t, "1.000000", values["marginTop"])
+	values, err = (&Client{Version: Gotenberg8}).formValues(req)
+	require.Nil(t, err)
+	assert.Equal(t, "210mm", values["paperWidth"])
+	assert.Equal(t, "210mm", req.formValues()["paperWidth"])
+}
+
+func TestVersionSince(t *testing.T) {
+	pdf, err := NewDocumentFromString("x.pdf", "%PDF-1.7")
+	require.Nil(t, err)
+	req := NewMergeRequest(pdf)
+	req.Encrypt("user", "")
+	req.PDFUA(true)
+	req.Embed(pdf)
+	var vErr *ValidationError
+	require.ErrorAs(t, req.Validate(), &vErr)
+	assert.Len(t, vErr.Errors, 3)
+	req.target(Gotenberg8)
+	assert.Nil(t, req.Validate())
+
+	flatten := NewFlattenRequest(pdf)
+	assert.NotNil(t, flatten.Validate())
+	flatten.target(Gotenberg8)
+	assert.Nil(t, flatten.Validate())
+
+	page := NewConvertURLRequest("https://example.com")
+	require.Nil(t, page.AddScopedExtraHTTPHeader("Authorization", "Bearer token", `https://api\.example\.com/.*`))
+	assert.NotNil(t, page.Validate())
+	page.target(Gotenberg8)
+	assert.Nil(t, page.Validate())
+}
+
+func TestVersionPost(t *testing.T) {
+	var pdfa string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pdfa = r.FormValue("pdfa")
+	}))
+	defer srv.Close()
+	req := NewConvertURLRequest("https://example.com")
+	req.PDFFormat(PDFA1b)
+	_, err := (&Client{Hostname: srv.URL}).Post(context.Background(), req)
+	assert.NotNil(t, err)
+	resp, err := (&Client{Hostname: srv.URL, Version: Gotenberg8}).Post(context.Background(), req)
+	require.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, "PDF/A-1b", pdfa)
+}
@@ -0,0 +1,88 @@
+package gotenberg
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// WaitCondition is a condition Chromium waits for before
+// converting a document, compiled to a JavaScript expression.
+type WaitCondition struct {
+	expr string
+}
+
+// Expression returns the JavaScript expression of the condition.
+func (c WaitCondition) Expression() string {
+	return c.expr
+}
+
+// SelectorExists waits for an element matching
+// the CSS selector to be in the document.
+func SelectorExists(selector string) WaitCondition {
+	return WaitCondition{fmt.Sprintf("document.querySelector(%s) !== null", jsString(selector))}
+}
+
+// SelectorVisible waits for an element matching
+// the CSS selector to be in the document and visible.
+func SelectorVisible(selector string) WaitCondition {
+	return WaitCondition{fmt.Sprintf(
+		"(() => { const el = document.querySelector(%s); if (el === null) { return false; } "+
+			"const style = window.getComputedStyle(el); "+
+			"return style.display !== 'none' && style.visibility !== 'hidden' && el.getClientRects().length > 0; })()",
+		jsString(selector),
+	)}
+}
+
+// WindowFlag waits for a property of window to be true,
+// e.g. window.renderDone set by the page once rendered.
+func WindowFlag(name string) WaitCondition {
+	return WaitCondition{fmt.Sprintf("window[%s] === true", jsString(name))}
+}
+
+// ImagesLoaded waits for every image of the document to be loaded.
+func ImagesLoaded() WaitCondition {
+	return WaitCondition{"Array.from(document.images).every((img) => img.complete)"}
+}
+
+// FontsReady waits for every font of the document to be loaded.
+func FontsReady() WaitCondition {
+	return WaitCondition{"document.fonts.status === 'loaded'"}
+}
+
+// And waits for every condition to be met.
+func And(conditions ...WaitCondition) WaitCondition {
+	return combine(" && ", "true", conditions)
+}
+
+// Or waits for any of the conditions to be met.
+func Or(conditions ...WaitCondition) WaitCondition {
+	return combine(" || ", "false", conditions)
+}
+
+// Not waits for a condition not to be met.
+func Not(condition WaitCondition) WaitCondition {
+	return WaitCondition{fmt.Sprintf("!(%s)", condition.expr)}
+}
+
+func combine(operator, empty string, conditions []WaitCondition) WaitCondition {
+	switch len(conditions) {
+	case 0:
+		return WaitCondition{empty}
+	case 1:
+		return conditions[0]
+	}
+	exprs := make([]string, len(conditions))
+	for i, c := range conditions {
+		exprs[i] = fmt.Sprintf("(%s)", c.expr)
+	}
+	return WaitCondition{strings.Join(exprs, operator)}
+}
+
+// jsString returns s as a JavaScript string literal.
+func jsString(s string) string {
+	// JSON strings are valid JavaScript string literals, as encoding/json
+	// also escapes the U+2028 and U+2029 line terminators.
+	b, _ := json.Marshal(s) // nolint: errcheck
+	return string(b)
+}
@@ -0,0 +1,25 @@
+package gotenberg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitFor(t *testing.T) {
+	assert.Equal(t, `document.querySelector("#chart") !== null`, SelectorExists("#chart").Expression())
+	assert.Equal(t, `window["render\"Done"] === true`, WindowFlag(`render"Done`).Expression())
+	assert.Equal(t, `document.querySelector("\u003c/script\u003e\u2028") !== null`, SelectorExists("</script>\u2028").Expression())
+	assert.Equal(t, "true", And().Expression())
+	assert.Equal(t, "false", Or().Expression())
+	assert.Equal(t, FontsReady(), And(FontsReady()))
+	assert.Equal(t,
+		`(window["renderDone"] === true) && ((Array.from(document.images).every((img) => img.complete)) || (!(document.fonts.status === 'loaded')))`,
+		And(WindowFlag("renderDone"), Or(ImagesLoaded(), Not(FontsReady()))).Expression(),
+	)
+
+	req := NewConvertURLRequest("https://example.com")
+	req.WaitFor(SelectorVisible("#chart"), WindowFlag("renderDone"))
+	assert.Contains(t, req.formValues()[waitForExpression], `document.querySelector("#chart")`)
+	assert.Contains(t, req.formValues()[waitForExpression], ` && (window["renderDone"] === true)`)
+}